@@ -1,32 +1,186 @@
 package muchtest
 
 import (
-	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/grongor/go-muchtest/internal"
 	"github.com/grongor/go-muchtest/match"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+var _ TestifyInterface = (*Assertions)(nil)
+
+func NewAssertions(t TestingT) *Assertions {
+	return &Assertions{t: t}
+}
+
 type Assertions struct {
 	t TestingT
+
+	parent         *Assertions
+	failures       *[]string
+	messageAndArgs []any
+}
+
+func (a *Assertions) Check(fn func(a *Assertions), messageAndArgs ...any) {
+	a.t.Helper()
+
+	soft := &Assertions{t: a.t, parent: a, failures: new([]string), messageAndArgs: messageAndArgs}
+
+	// Report also when fn stops the goroutine, e.g. by calling t.FailNow() directly, or when it panics; the panic
+	// must keep going then, so the failures are reported without stopping the goroutine.
+	defer func() {
+		if r := recover(); r != nil {
+			soft.reportPanicking()
+
+			panic(r)
+		}
+
+		soft.report()
+	}()
+
+	fn(soft)
 }
 
 func (a *Assertions) Fail(message string, messageAndArgs ...any) {
 	a.t.Helper()
 
-	require.Fail(a.t, message, messageAndArgs...)
+	a.fail(message, messageAndArgs)
+}
+
+func (a *Assertions) FailNow(message string, messageAndArgs ...any) {
+	a.t.Helper()
+
+	if a.failures == nil {
+		require.FailNow(a.t, message, messageAndArgs...)
+
+		return
+	}
+
+	a.fail(message, messageAndArgs)
+
+	for ; a.failures != nil; a = a.parent {
+		a.report()
+	}
+
+	a.t.FailNow()
 }
 
 func (a *Assertions) Match(matcher match.Matcher, actual any, messageAndArgs ...any) {
 	a.t.Helper()
 
 	if ok, desc := matcher.Matches(actual); !ok {
-		require.Fail(a.t, desc, messageAndArgs...)
+		a.fail(desc, messageAndArgs)
+	}
+}
+
+func (a *Assertions) Snapshot(name string, actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	namer, ok := a.t.(interface{ Name() string })
+	if !ok {
+		a.fail(fmt.Sprintf("Snapshot(%q): TestingT doesn't implement Name() string", name), messageAndArgs)
+
+		return
+	}
+
+	path := filepath.Join(internal.SnapshotsDir, filepath.FromSlash(namer.Name()), name)
+
+	a.Match(match.Snapshot(path), actual, messageAndArgs...)
+}
+
+func (a *Assertions) fail(message string, messageAndArgs []any) {
+	a.t.Helper()
+
+	if a.failures == nil {
+		require.Fail(a.t, message, messageAndArgs...)
+
+		return
+	}
+
+	if messages := formatMessageAndArgs(messageAndArgs); messages != "" {
+		message += "\nMessages: " + messages
+	}
+
+	*a.failures = append(*a.failures, message)
+}
+
+func (a *Assertions) report() {
+	a.t.Helper()
+
+	if message, ok := a.failuresString(); ok {
+		a.parent.fail(message, a.messageAndArgs)
+	}
+}
+
+func (a *Assertions) reportPanicking() {
+	a.t.Helper()
+
+	message, ok := a.failuresString()
+	if !ok {
+		return
+	}
+
+	if a.parent.failures == nil {
+		assert.Fail(a.t, message, a.messageAndArgs...)
+
+		return
+	}
+
+	a.parent.fail(message, a.messageAndArgs)
+}
+
+func (a *Assertions) failuresString() (string, bool) {
+	failures := *a.failures
+	if len(failures) == 0 {
+		return "", false
+	}
+
+	*a.failures = nil
+
+	builder := &strings.Builder{}
+
+	if len(failures) == 1 {
+		builder.WriteString("Check(): 1 assertion failed:\n")
+	} else {
+		fmt.Fprintf(builder, "Check(): %d assertions failed:\n", len(failures))
+	}
+
+	for i, failure := range failures {
+		number := fmt.Sprintf("\t%d) ", i+1)
+
+		builder.WriteString(number)
+		builder.WriteString(strings.ReplaceAll(failure, "\n", "\n\t"+strings.Repeat(" ", len(number)-1)))
+		builder.WriteByte('\n')
+	}
+
+	return builder.String(), true
+}
+
+func formatMessageAndArgs(messageAndArgs []any) string {
+	switch len(messageAndArgs) {
+	case 0:
+		return ""
+	case 1:
+		if message, ok := messageAndArgs[0].(string); ok {
+			return message
+		}
+
+		return fmt.Sprintf("%+v", messageAndArgs[0])
+	default:
+		if format, ok := messageAndArgs[0].(string); ok {
+			return fmt.Sprintf(format, messageAndArgs[1:]...)
+		}
+
+		return fmt.Sprint(messageAndArgs...)
 	}
 }
 
@@ -45,87 +199,510 @@ func (a *Assertions) NotEqual(expected, actual any, messageAndArgs ...any) {
 func (a *Assertions) EqualOpt(options []cmp.Option, expected, actual any, messageAndArgs ...any) {
 	a.t.Helper()
 
-	if ok, desc := match.Equal(expected, options...).Matches(actual); !ok {
-		require.Fail(a.t, desc, messageAndArgs)
-	}
+	a.Match(match.Equal(expected, options...), actual, messageAndArgs...)
 }
 
 func (a *Assertions) NotEqualOpt(options []cmp.Option, expected, actual any, messageAndArgs ...any) {
 	a.t.Helper()
 
-	if ok, desc := match.Equal(expected, options...).Matches(actual); ok {
-		require.Fail(a.t, desc, messageAndArgs)
-	}
+	a.Match(match.Not(match.Equal(expected, options...)), actual, messageAndArgs...)
+}
+
+func (a *Assertions) EqualValues(expected, actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Equal(expected, actual, messageAndArgs...)
+}
+
+func (a *Assertions) NotEqualValues(expected, actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.NotEqual(expected, actual, messageAndArgs...)
+}
+
+func (a *Assertions) Exactly(expected, actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.All(match.Type(expected), match.Equal(expected)), actual, messageAndArgs...)
+}
+
+func (a *Assertions) Same(expected, actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.SamePointer(expected), actual, messageAndArgs...)
+}
+
+func (a *Assertions) NotSame(expected, actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Not(match.SamePointer(expected)), actual, messageAndArgs...)
 }
 
 func (a *Assertions) Nil(actual any, messageAndArgs ...any) {
 	a.t.Helper()
 
-	if actual != nil {
-		require.Fail(a.t, fmt.Sprintf("Expected nil value, got: %#v", actual), messageAndArgs)
-	}
+	a.Match(match.Nil(), actual, messageAndArgs...)
 }
 
 func (a *Assertions) NotNil(actual any, messageAndArgs ...any) {
 	a.t.Helper()
 
-	if actual == nil {
-		require.Fail(a.t, "Expected not nil value", messageAndArgs)
-	}
+	a.Match(match.Not(match.Nil()), actual, messageAndArgs...)
 }
 
 func (a *Assertions) Empty(actual any, messageAndArgs ...any) {
-	require.Empty(a.t, actual, messageAndArgs)
+	a.t.Helper()
+
+	a.Match(match.Empty(), actual, messageAndArgs...)
+}
+
+func (a *Assertions) NotEmpty(actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Not(match.Empty()), actual, messageAndArgs...)
+}
+
+func (a *Assertions) Zero(actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Zero(), actual, messageAndArgs...)
+}
+
+func (a *Assertions) NotZero(actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Not(match.Zero()), actual, messageAndArgs...)
 }
 
 func (a *Assertions) True(actual bool, messageAndArgs ...any) {
-	require.True(a.t, actual, messageAndArgs...)
+	a.t.Helper()
+
+	a.Match(match.Equal(true), actual, messageAndArgs...)
 }
 
 func (a *Assertions) False(actual bool, messageAndArgs ...any) {
-	require.False(a.t, actual, messageAndArgs...)
+	a.t.Helper()
+
+	a.Match(match.Equal(false), actual, messageAndArgs...)
 }
 
 func (a *Assertions) Len(n int, actual any, messageAndArgs ...any) {
-	if ok, desc := match.Len(n).Matches(actual); !ok {
-		require.Fail(a.t, desc, messageAndArgs)
+	a.t.Helper()
+
+	a.Match(match.Len(n), actual, messageAndArgs...)
+}
+
+func (a *Assertions) Contains(container, element any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(containsMatcher(container, element), container, messageAndArgs...)
+}
+
+func (a *Assertions) NotContains(container, element any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	if isNilContainer(container) {
+		a.fail("NotContains(): not a container: nil", messageAndArgs)
+
+		return
+	}
+
+	a.Match(match.Not(containsMatcher(container, element)), container, messageAndArgs...)
+}
+
+// isNilContainer reports whether the container is nil or a chain of pointers ending in nil, which testify treats as
+// a failure even for the negated assertion.
+func isNilContainer(container any) bool {
+	v := reflect.ValueOf(container)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	return !v.IsValid()
+}
+
+// containsMatcher looks for keys in maps, as testify does.
+func containsMatcher(container, element any) match.Matcher {
+	if reflect.ValueOf(container).Kind() == reflect.Map {
+		return match.ContainsKey(element)
+	}
+
+	return match.Contains(element)
+}
+
+func (a *Assertions) Subset(list, subset any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	matcher, err := subsetMatcher(subset)
+	if err != "" {
+		a.Fail("Subset(): "+err, messageAndArgs...)
+
+		return
 	}
+
+	a.Match(matcher, list, messageAndArgs...)
+}
+
+func (a *Assertions) NotSubset(list, subset any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	matcher, err := subsetMatcher(subset)
+	if err != "" {
+		a.Fail("NotSubset(): "+err, messageAndArgs...)
+
+		return
+	}
+
+	if subset != nil && isNilContainer(list) {
+		a.fail("NotSubset(): not a container: nil", messageAndArgs)
+
+		return
+	}
+
+	a.Match(match.Not(matcher), list, messageAndArgs...)
+}
+
+func subsetMatcher(subset any) (match.Matcher, string) {
+	vSubset := reflect.ValueOf(subset)
+
+	var matchers []any
+
+	switch vSubset.Kind() {
+	case reflect.Invalid:
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < vSubset.Len(); i++ {
+			matchers = append(matchers, match.Contains(vSubset.Index(i).Interface()))
+		}
+	case reflect.Map:
+		keys := vSubset.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+
+		for _, key := range keys {
+			matchers = append(matchers, match.ContainsKeyValue(key.Interface(), vSubset.MapIndex(key).Interface()))
+		}
+	default:
+		return nil, fmt.Sprintf("subset must be an array, slice or map, got %T", subset)
+	}
+
+	return match.All(matchers...), ""
+}
+
+func (a *Assertions) ElementsMatch(expected, actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	vExpected := reflect.ValueOf(expected)
+
+	var elements []any
+
+	switch vExpected.Kind() {
+	case reflect.Invalid:
+	case reflect.Array, reflect.Slice:
+		elements = make([]any, vExpected.Len())
+
+		for i := range elements {
+			elements[i] = vExpected.Index(i).Interface()
+		}
+	default:
+		a.Fail(fmt.Sprintf("ElementsMatch(): expected must be an array or slice, got %T", expected), messageAndArgs...)
+
+		return
+	}
+
+	if actual == nil {
+		actual = []any{}
+	}
+
+	a.Match(match.ElementsMatch(elements...), actual, messageAndArgs...)
+}
+
+func (a *Assertions) Greater(e1, e2 any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Greater(e2), e1, messageAndArgs...)
+}
+
+func (a *Assertions) GreaterOrEqual(e1, e2 any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.GreaterOrEqual(e2), e1, messageAndArgs...)
+}
+
+func (a *Assertions) Less(e1, e2 any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Less(e2), e1, messageAndArgs...)
+}
+
+func (a *Assertions) LessOrEqual(e1, e2 any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.LessOrEqual(e2), e1, messageAndArgs...)
+}
+
+func (a *Assertions) Positive(actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Positive(), actual, messageAndArgs...)
+}
+
+func (a *Assertions) Negative(actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Negative(), actual, messageAndArgs...)
+}
+
+func (a *Assertions) IsIncreasing(actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Increasing(), actual, messageAndArgs...)
+}
+
+func (a *Assertions) IsNonIncreasing(actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.NonIncreasing(), actual, messageAndArgs...)
+}
+
+func (a *Assertions) IsDecreasing(actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Decreasing(), actual, messageAndArgs...)
+}
+
+func (a *Assertions) IsNonDecreasing(actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.NonDecreasing(), actual, messageAndArgs...)
+}
+
+func (a *Assertions) InDelta(expected, actual any, delta float64, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.InDelta(expected, delta), actual, messageAndArgs...)
+}
+
+func (a *Assertions) InDeltaSlice(expected, actual any, delta float64, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.InDelta(expected, actual, delta, messageAndArgs...)
+}
+
+func (a *Assertions) InDeltaMapValues(expected, actual any, delta float64, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.InDelta(expected, actual, delta, messageAndArgs...)
+}
+
+func (a *Assertions) InEpsilon(expected, actual any, epsilon float64, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.InEpsilon(expected, epsilon), actual, messageAndArgs...)
+}
+
+func (a *Assertions) InEpsilonSlice(expected, actual any, epsilon float64, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.InEpsilon(expected, actual, epsilon, messageAndArgs...)
+}
+
+func (a *Assertions) IsType(expectedType, actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Type(expectedType), actual, messageAndArgs...)
+}
+
+func (a *Assertions) Implements(iface, actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Implements(iface), actual, messageAndArgs...)
 }
 
 func (a *Assertions) Error(expected any, actual error, messageAndArgs ...any) {
+	a.t.Helper()
+
+	var matcher match.Matcher
+
 	switch e := expected.(type) {
+	case nil:
+		matcher = match.Not(match.Nil())
 	case error:
-		if e != actual && e.Error() != actual.Error() && !errors.Is(e, actual) {
-			require.Fail(a.t, fmt.Sprintf("Expected different error: %#v", actual), messageAndArgs...)
-		}
+		matcher = match.AnyOf(match.ErrorIs(e), match.ErrorMsg(e.Error()))
 	case string:
-		if strings.Contains(actual.Error(), e) {
-			require.Fail(a.t, fmt.Sprintf(`Expected different error message "%s": %s`, e, actual), messageAndArgs...)
-		}
-	case match.Matcher:
-		if ok, desc := e.Matches(actual); !ok {
-			require.Fail(a.t, fmt.Sprintf("Error doesn't match expectations: %s: %#v", desc, actual))
-		}
+		matcher = match.ErrorMsg(match.Contains(e))
 	default:
-		if expected == nil {
-			if actual == nil {
-				require.Fail(a.t, "Expected any error, got nil")
-			}
+		matcher = match.ToMatcher(expected)
+	}
+
+	a.Match(matcher, actual, messageAndArgs...)
+}
+
+func (a *Assertions) NoError(actual error, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Nil(), actual, messageAndArgs...)
+}
+
+func (a *Assertions) EqualError(actual error, expected string, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.ErrorMsg(expected), actual, messageAndArgs...)
+}
+
+func (a *Assertions) ErrorContains(actual error, contains string, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.ErrorMsg(match.Contains(contains)), actual, messageAndArgs...)
+}
+
+func (a *Assertions) ErrorIs(actual, target error, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.ErrorIs(target), actual, messageAndArgs...)
+}
+
+func (a *Assertions) NotErrorIs(actual, target error, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Not(match.ErrorIs(target)), actual, messageAndArgs...)
+}
+
+func (a *Assertions) ErrorAs(actual error, target any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.ErrorAs(target), actual, messageAndArgs...)
+}
+
+func (a *Assertions) Regexp(regexp, actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Regexp(regexp), actual, messageAndArgs...)
+}
+
+func (a *Assertions) NotRegexp(regexp, actual any, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Not(match.Regexp(regexp)), actual, messageAndArgs...)
+}
+
+func (a *Assertions) Panics(fn assert.PanicTestFunc, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Panics(), fn, messageAndArgs...)
+}
+
+func (a *Assertions) PanicsWithValue(expected any, fn assert.PanicTestFunc, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Panics(expected), fn, messageAndArgs...)
+}
+
+func (a *Assertions) PanicsWithError(expected string, fn assert.PanicTestFunc, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.Panics(match.ErrorMsg(expected)), fn, messageAndArgs...)
+}
+
+func (a *Assertions) NotPanics(fn assert.PanicTestFunc, messageAndArgs ...any) {
+	a.t.Helper()
+
+	a.Match(match.NotPanics(), fn, messageAndArgs...)
+}
+
+func (a *Assertions) DirExists(path string, messageAndArgs ...any) {
+	a.t.Helper()
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			a.Fail(fmt.Sprintf("DirExists(%q): no such directory", path), messageAndArgs...)
 
 			return
 		}
 
-		a.Equal(expected, actual, messageAndArgs...)
+		a.Fail(fmt.Sprintf("DirExists(%q): %s", path, err), messageAndArgs...)
+
+		return
+	}
+
+	if !info.IsDir() {
+		a.Fail(fmt.Sprintf("DirExists(%q): not a directory", path), messageAndArgs...)
 	}
 }
 
-func (a *Assertions) NoError(actual error, messageAndArgs ...any) {
-	require.NoError(a.t, actual, messageAndArgs...)
+func (a *Assertions) Eventually(condition func() bool, waitFor, tick time.Duration, messageAndArgs ...any) {
+	a.t.Helper()
+
+	p, err := newPoller(condition, match.Equal(true), []PollOption{WaitFor(waitFor), Tick(tick)})
+	if err != "" {
+		a.Fail("Eventually(): "+err, messageAndArgs...)
+
+		return
+	}
+
+	if ok, _ := p.poll(true); !ok {
+		a.Fail(fmt.Sprintf("Eventually(): condition not satisfied within %s", waitFor), messageAndArgs...)
+	}
 }
 
-func (a *Assertions) Regexp(regexp, actual any, messageAndArgs ...any) {
-	if ok, desc := match.Regexp(regexp).Matches(actual); !ok {
-		require.Fail(a.t, desc, messageAndArgs...)
+// EventuallyMatch asserts that the value returned by fn will match the matcher within the WaitFor time (1s by
+// default), periodically calling fn each Tick (10ms by default). When AdvanceClock is given, the fake clock is advanced
+// by the tick between the polls instead of sleeping. It's the matcher-based counterpart of Eventually, which keeps
+// the testify signature (condition, waitFor, tick).
+//
+//	a.EventuallyMatch(func() int { return len(queue) }, 3, muchtest.WaitFor(time.Second))
+//	a.EventuallyMatch(service.State, match.Equal("done"), muchtest.AdvanceClock(clock))
+func (a *Assertions) EventuallyMatch(fn any, matcher any, optionsAndMessageAndArgs ...any) {
+	a.t.Helper()
+
+	options, messageAndArgs := splitPollOptions(optionsAndMessageAndArgs)
+
+	p, err := newPoller(fn, match.ToMatcher(matcher), options)
+	if err != "" {
+		a.Fail("EventuallyMatch(): "+err, messageAndArgs...)
+
+		return
+	}
+
+	if ok, desc := p.poll(true); !ok {
+		a.Fail(fmt.Sprintf("EventuallyMatch(): not matched within %s (%s): %s", p.waitFor, p.pollsString(), desc),
+			messageAndArgs...)
+	}
+}
+
+// Consistently asserts that the value returned by fn keeps matching the matcher for the whole WaitFor time. It accepts
+// the same options as EventuallyMatch.
+func (a *Assertions) Consistently(fn any, matcher any, optionsAndMessageAndArgs ...any) {
+	a.t.Helper()
+
+	options, messageAndArgs := splitPollOptions(optionsAndMessageAndArgs)
+
+	p, err := newPoller(fn, match.ToMatcher(matcher), options)
+	if err != "" {
+		a.Fail("Consistently(): "+err, messageAndArgs...)
+
+		return
+	}
+
+	if ok, desc := p.poll(false); !ok {
+		a.Fail(fmt.Sprintf("Consistently(): not matched after %s (%s): %s", p.elapsed, p.pollsString(), desc),
+			messageAndArgs...)
+	}
+}
+
+func (a *Assertions) Never(condition func() bool, waitFor, tick time.Duration, messageAndArgs ...any) {
+	a.t.Helper()
+
+	p, err := newPoller(condition, match.Equal(false), []PollOption{WaitFor(waitFor), Tick(tick)})
+	if err != "" {
+		a.Fail("Never(): "+err, messageAndArgs...)
+
+		return
+	}
+
+	if ok, _ := p.poll(false); !ok {
+		a.Fail(fmt.Sprintf("Never(): condition satisfied within %s", waitFor), messageAndArgs...)
 	}
 }
 
@@ -135,323 +712,323 @@ type TestifyInterface interface {
 	// Contains asserts that the specified string, list(array, slice...) or map contains the
 	// specified substring or element.
 	//
-	//	assert.Contains(t, "Hello World", "World")
-	//	assert.Contains(t, ["Hello", "World"], "World")
-	//	assert.Contains(t, {"Hello": "World"}, "Hello")
-	Contains(t TestingT, s interface{}, contains interface{}, msgAndArgs ...interface{})
+	//	a.Contains("Hello World", "World")
+	//	a.Contains(["Hello", "World"], "World")
+	//	a.Contains({"Hello": "World"}, "Hello")
+	Contains(s any, contains any, messageAndArgs ...any)
 
 	// DirExists checks whether a directory exists in the given path. It also fails
 	// if the path is a file rather a directory or there is an error checking whether it exists.
-	DirExists(t TestingT, path string, msgAndArgs ...interface{})
+	DirExists(path string, messageAndArgs ...any)
 
 	// ElementsMatch asserts that the specified listA(array, slice...) is equal to specified
 	// listB(array, slice...) ignoring the order of the elements. If there are duplicate elements,
 	// the number of appearances of each of them in both lists should match.
 	//
-	// assert.ElementsMatch(t, [1, 3, 2, 3], [1, 3, 3, 2])
-	ElementsMatch(t TestingT, listA interface{}, listB interface{}, msgAndArgs ...interface{})
+	// a.ElementsMatch([1, 3, 2, 3], [1, 3, 3, 2])
+	ElementsMatch(listA any, listB any, messageAndArgs ...any)
 
 	// Empty asserts that the specified object is empty.  I.e. nil, "", false, 0 or either
 	// a slice or a channel with len == 0.
 	//
-	//	assert.Empty(t, obj)
-	Empty(t TestingT, object interface{}, msgAndArgs ...interface{})
+	//	a.Empty(obj)
+	Empty(object any, messageAndArgs ...any)
 
 	// Equal asserts that two objects are equal.
 	//
-	//	assert.Equal(t, 123, 123)
+	//	a.Equal(123, 123)
 	//
 	// Pointer variable equality is determined based on the equality of the
 	// referenced values (as opposed to the memory addresses). Function equality
 	// cannot be determined and will always fail.
-	Equal(t TestingT, expected interface{}, actual interface{}, msgAndArgs ...interface{})
+	Equal(expected any, actual any, messageAndArgs ...any)
 
 	// EqualError asserts that a function returned an error (i.e. not `nil`)
 	// and that it is equal to the provided error.
 	//
 	//	actualObj, err := SomeFunction()
-	//	assert.EqualError(t, err,  expectedErrorString)
-	EqualError(t TestingT, theError error, errString string, msgAndArgs ...interface{})
+	//	a.EqualError(err,  expectedErrorString)
+	EqualError(theError error, errString string, messageAndArgs ...any)
 	// EqualValues asserts that two objects are equal or convertable to the same types
 	// and equal.
 	//
-	//	assert.EqualValues(t, uint32(123), int32(123))
-	EqualValues(t TestingT, expected interface{}, actual interface{}, msgAndArgs ...interface{})
+	//	a.EqualValues(uint32(123), int32(123))
+	EqualValues(expected any, actual any, messageAndArgs ...any)
 
-	// Error asserts that a function returned an error (i.e. not `nil`).
+	// Error asserts that a function returned an error matching the expectation. Expected error is compared
+	// using errors.Is() or by its message, string must be contained in the error message, matcher is applied
+	// to the error and nil means any (not `nil`) error.
 	//
-	//	  actualObj, err := SomeFunction()
-	//	  if assert.Error(t, err) {
-	//		   assert.Equal(t, expectedError, err)
-	//	  }
-	Error(t TestingT, err error, msgAndArgs ...interface{})
+	//	actualObj, err := SomeFunction()
+	//	a.Error(expectedError, err)
+	//	a.Error("connection refused", err)
+	//	a.Error(nil, err)
+	Error(expected any, actual error, messageAndArgs ...any)
 
 	// ErrorAs asserts that at least one of the errors in err's chain matches target, and if so, sets target to that error value.
 	// This is a wrapper for errors.As.
-	ErrorAs(t TestingT, err error, target interface{}, msgAndArgs ...interface{})
+	ErrorAs(err error, target any, messageAndArgs ...any)
 
 	// ErrorContains asserts that a function returned an error (i.e. not `nil`)
 	// and that the error contains the specified substring.
 	//
 	//	actualObj, err := SomeFunction()
-	//	assert.ErrorContains(t, err,  expectedErrorSubString)
-	ErrorContains(t TestingT, theError error, contains string, msgAndArgs ...interface{})
+	//	a.ErrorContains(err,  expectedErrorSubString)
+	ErrorContains(theError error, contains string, messageAndArgs ...any)
 
 	// ErrorIs asserts that at least one of the errors in err's chain matches target.
 	// This is a wrapper for errors.Is.
-	ErrorIs(t TestingT, err error, target error, msgAndArgs ...interface{})
+	ErrorIs(err error, target error, messageAndArgs ...any)
 
 	// Eventually asserts that given condition will be met in waitFor time,
 	// periodically checking target function each tick.
 	//
-	//	assert.Eventually(t, func() bool { return true; }, time.Second, 10*time.Millisecond)
-	Eventually(t TestingT, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{})
+	//	a.Eventually(func() bool { return true; }, time.Second, 10*time.Millisecond)
+	Eventually(condition func() bool, waitFor time.Duration, tick time.Duration, messageAndArgs ...any)
 
 	// Exactly asserts that two objects are equal in value and type.
 	//
-	//	assert.Exactly(t, int32(123), int64(123))
-	Exactly(t TestingT, expected interface{}, actual interface{}, msgAndArgs ...interface{})
+	//	a.Exactly(int32(123), int64(123))
+	Exactly(expected any, actual any, messageAndArgs ...any)
 
-	// Fail reports a failure through
-	Fail(t TestingT, failureMessage string, msgAndArgs ...interface{})
+	// Fail reports a failure. Like every other assertion, it stops the test, unless it's called inside Check, where
+	// the failure is collected and the check block continues.
+	Fail(failureMessage string, messageAndArgs ...any)
 
-	// FailNow fails test
-	FailNow(t TestingT, failureMessage string, msgAndArgs ...interface{})
+	// FailNow reports a failure and stops the test. Inside Check, it first reports the failures collected so far.
+	FailNow(failureMessage string, messageAndArgs ...any)
 
 	// False asserts that the specified value is false.
 	//
-	//	assert.False(t, myBool)
-	False(t TestingT, value bool, msgAndArgs ...interface{})
+	//	a.False(myBool)
+	False(value bool, messageAndArgs ...any)
 
 	// Greater asserts that the first element is greater than the second
 	//
-	//	assert.Greater(t, 2, 1)
-	//	assert.Greater(t, float64(2), float64(1))
-	//	assert.Greater(t, "b", "a")
-	Greater(t TestingT, e1 interface{}, e2 interface{}, msgAndArgs ...interface{})
+	//	a.Greater(2, 1)
+	//	a.Greater(float64(2), float64(1))
+	//	a.Greater("b", "a")
+	Greater(e1 any, e2 any, messageAndArgs ...any)
 
 	// GreaterOrEqual asserts that the first element is greater than or equal to the second
 	//
-	//	assert.GreaterOrEqual(t, 2, 1)
-	//	assert.GreaterOrEqual(t, 2, 2)
-	//	assert.GreaterOrEqual(t, "b", "a")
-	//	assert.GreaterOrEqual(t, "b", "b")
-	GreaterOrEqual(t TestingT, e1 interface{}, e2 interface{}, msgAndArgs ...interface{})
+	//	a.GreaterOrEqual(2, 1)
+	//	a.GreaterOrEqual(2, 2)
+	//	a.GreaterOrEqual("b", "a")
+	//	a.GreaterOrEqual("b", "b")
+	GreaterOrEqual(e1 any, e2 any, messageAndArgs ...any)
 
 	// Implements asserts that an object is implemented by the specified interface.
 	//
-	//	assert.Implements(t, (*MyInterface)(nil), new(MyObject))
-	Implements(t TestingT, interfaceObject interface{}, object interface{}, msgAndArgs ...interface{})
+	//	a.Implements((*MyInterface)(nil), new(MyObject))
+	Implements(interfaceObject any, object any, messageAndArgs ...any)
 
 	// InDelta asserts that the two numerals are within delta of each other.
 	//
-	//	assert.InDelta(t, math.Pi, 22/7.0, 0.01)
-	InDelta(t TestingT, expected interface{}, actual interface{}, delta float64, msgAndArgs ...interface{})
+	//	a.InDelta(math.Pi, 22/7.0, 0.01)
+	InDelta(expected any, actual any, delta float64, messageAndArgs ...any)
 
 	// InDeltaMapValues is the same as InDelta, but it compares all values between two maps. Both maps must have exactly the same keys.
-	InDeltaMapValues(t TestingT, expected interface{}, actual interface{}, delta float64, msgAndArgs ...interface{})
+	InDeltaMapValues(expected any, actual any, delta float64, messageAndArgs ...any)
 
 	// InDeltaSlice is the same as InDelta, except it compares two slices.
-	InDeltaSlice(t TestingT, expected interface{}, actual interface{}, delta float64, msgAndArgs ...interface{})
+	InDeltaSlice(expected any, actual any, delta float64, messageAndArgs ...any)
 
 	// InEpsilon asserts that expected and actual have a relative error less than epsilon
-	InEpsilon(t TestingT, expected interface{}, actual interface{}, epsilon float64, msgAndArgs ...interface{})
+	InEpsilon(expected any, actual any, epsilon float64, messageAndArgs ...any)
 
 	// InEpsilonSlice is the same as InEpsilon, except it compares each value from two slices.
-	InEpsilonSlice(t TestingT, expected interface{}, actual interface{}, epsilon float64, msgAndArgs ...interface{})
+	InEpsilonSlice(expected any, actual any, epsilon float64, messageAndArgs ...any)
 
 	// IsDecreasing asserts that the collection is decreasing
 	//
-	//	assert.IsDecreasing(t, []int{2, 1, 0})
-	//	assert.IsDecreasing(t, []float{2, 1})
-	//	assert.IsDecreasing(t, []string{"b", "a"})
-	IsDecreasing(t TestingT, object interface{}, msgAndArgs ...interface{})
+	//	a.IsDecreasing([]int{2, 1, 0})
+	//	a.IsDecreasing([]float{2, 1})
+	//	a.IsDecreasing([]string{"b", "a"})
+	IsDecreasing(object any, messageAndArgs ...any)
 
 	// IsIncreasing asserts that the collection is increasing
 	//
-	//	assert.IsIncreasing(t, []int{1, 2, 3})
-	//	assert.IsIncreasing(t, []float{1, 2})
-	//	assert.IsIncreasing(t, []string{"a", "b"})
-	IsIncreasing(t TestingT, object interface{}, msgAndArgs ...interface{})
+	//	a.IsIncreasing([]int{1, 2, 3})
+	//	a.IsIncreasing([]float{1, 2})
+	//	a.IsIncreasing([]string{"a", "b"})
+	IsIncreasing(object any, messageAndArgs ...any)
 
 	// IsNonDecreasing asserts that the collection is not decreasing
 	//
-	//	assert.IsNonDecreasing(t, []int{1, 1, 2})
-	//	assert.IsNonDecreasing(t, []float{1, 2})
-	//	assert.IsNonDecreasing(t, []string{"a", "b"})
-	IsNonDecreasing(t TestingT, object interface{}, msgAndArgs ...interface{})
+	//	a.IsNonDecreasing([]int{1, 1, 2})
+	//	a.IsNonDecreasing([]float{1, 2})
+	//	a.IsNonDecreasing([]string{"a", "b"})
+	IsNonDecreasing(object any, messageAndArgs ...any)
 
 	// IsNonIncreasing asserts that the collection is not increasing
 	//
-	//	assert.IsNonIncreasing(t, []int{2, 1, 1})
-	//	assert.IsNonIncreasing(t, []float{2, 1})
-	//	assert.IsNonIncreasing(t, []string{"b", "a"})
-	IsNonIncreasing(t TestingT, object interface{}, msgAndArgs ...interface{})
+	//	a.IsNonIncreasing([]int{2, 1, 1})
+	//	a.IsNonIncreasing([]float{2, 1})
+	//	a.IsNonIncreasing([]string{"b", "a"})
+	IsNonIncreasing(object any, messageAndArgs ...any)
 
 	// IsType asserts that the specified objects are of the same type.
-	IsType(t TestingT, expectedType interface{}, object interface{}, msgAndArgs ...interface{})
+	IsType(expectedType any, object any, messageAndArgs ...any)
 
 	// Len asserts that the specified object has specific length.
 	// Len also fails if the object has a type that len() not accept.
 	//
-	//	assert.Len(t, mySlice, 3)
-	Len(t TestingT, object interface{}, length int, msgAndArgs ...interface{})
+	//	a.Len(3, mySlice)
+	Len(n int, actual any, messageAndArgs ...any)
 
 	// Less asserts that the first element is less than the second
 	//
-	//	assert.Less(t, 1, 2)
-	//	assert.Less(t, float64(1), float64(2))
-	//	assert.Less(t, "a", "b")
-	Less(t TestingT, e1 interface{}, e2 interface{}, msgAndArgs ...interface{})
+	//	a.Less(1, 2)
+	//	a.Less(float64(1), float64(2))
+	//	a.Less("a", "b")
+	Less(e1 any, e2 any, messageAndArgs ...any)
 
 	// LessOrEqual asserts that the first element is less than or equal to the second
 	//
-	//	assert.LessOrEqual(t, 1, 2)
-	//	assert.LessOrEqual(t, 2, 2)
-	//	assert.LessOrEqual(t, "a", "b")
-	//	assert.LessOrEqual(t, "b", "b")
-	LessOrEqual(t TestingT, e1 interface{}, e2 interface{}, msgAndArgs ...interface{})
+	//	a.LessOrEqual(1, 2)
+	//	a.LessOrEqual(2, 2)
+	//	a.LessOrEqual("a", "b")
+	//	a.LessOrEqual("b", "b")
+	LessOrEqual(e1 any, e2 any, messageAndArgs ...any)
 
 	// Negative asserts that the specified element is negative
 	//
-	//	assert.Negative(t, -1)
-	//	assert.Negative(t, -1.23)
-	Negative(t TestingT, e interface{}, msgAndArgs ...interface{})
+	//	a.Negative(-1)
+	//	a.Negative(-1.23)
+	Negative(e any, messageAndArgs ...any)
 
 	// Never asserts that the given condition doesn't satisfy in waitFor time,
 	// periodically checking the target function each tick.
 	//
-	//	assert.Never(t, func() bool { return false; }, time.Second, 10*time.Millisecond)
-	Never(t TestingT, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{})
+	//	a.Never(func() bool { return false; }, time.Second, 10*time.Millisecond)
+	Never(condition func() bool, waitFor time.Duration, tick time.Duration, messageAndArgs ...any)
 
 	// Nil asserts that the specified object is nil.
 	//
-	//	assert.Nil(t, err)
-	Nil(t TestingT, object interface{}, msgAndArgs ...interface{})
+	//	a.Nil(err)
+	Nil(object any, messageAndArgs ...any)
 
 	// NoError asserts that a function returned no error (i.e. `nil`).
 	//
-	//	  actualObj, err := SomeFunction()
-	//	  if assert.NoError(t, err) {
-	//		   assert.Equal(t, expectedObj, actualObj)
-	//	  }
-	NoError(t TestingT, err error, msgAndArgs ...interface{})
+	//	actualObj, err := SomeFunction()
+	//	a.NoError(err)
+	//	a.Equal(expectedObj, actualObj)
+	NoError(err error, messageAndArgs ...any)
 
 	// NotContains asserts that the specified string, list(array, slice...) or map does NOT contain the
 	// specified substring or element.
 	//
-	//	assert.NotContains(t, "Hello World", "Earth")
-	//	assert.NotContains(t, ["Hello", "World"], "Earth")
-	//	assert.NotContains(t, {"Hello": "World"}, "Earth")
-	NotContains(t TestingT, s interface{}, contains interface{}, msgAndArgs ...interface{})
+	//	a.NotContains("Hello World", "Earth")
+	//	a.NotContains(["Hello", "World"], "Earth")
+	//	a.NotContains({"Hello": "World"}, "Earth")
+	NotContains(s any, contains any, messageAndArgs ...any)
 
 	// NotEmpty asserts that the specified object is NOT empty.  I.e. not nil, "", false, 0 or either
 	// a slice or a channel with len == 0.
 	//
-	//	if assert.NotEmpty(t, obj) {
-	//	  assert.Equal(t, "two", obj[1])
-	//	}
-	NotEmpty(t TestingT, object interface{}, msgAndArgs ...interface{})
+	//	a.NotEmpty(obj)
+	NotEmpty(object any, messageAndArgs ...any)
 
 	// NotEqual asserts that the specified values are NOT equal.
 	//
-	//	assert.NotEqual(t, obj1, obj2)
+	//	a.NotEqual(obj1, obj2)
 	//
 	// Pointer variable equality is determined based on the equality of the
 	// referenced values (as opposed to the memory addresses).
-	NotEqual(t TestingT, expected interface{}, actual interface{}, msgAndArgs ...interface{})
+	NotEqual(expected any, actual any, messageAndArgs ...any)
 
 	// NotEqualValues asserts that two objects are not equal even when converted to the same type
 	//
-	//	assert.NotEqualValues(t, obj1, obj2)
-	NotEqualValues(t TestingT, expected interface{}, actual interface{}, msgAndArgs ...interface{})
+	//	a.NotEqualValues(obj1, obj2)
+	NotEqualValues(expected any, actual any, messageAndArgs ...any)
 
 	// NotErrorIs asserts that at none of the errors in err's chain matches target.
 	// This is a wrapper for errors.Is.
-	NotErrorIs(t TestingT, err error, target error, msgAndArgs ...interface{})
+	NotErrorIs(err error, target error, messageAndArgs ...any)
 
 	// NotNil asserts that the specified object is not nil.
 	//
-	//	assert.NotNil(t, err)
-	NotNil(t TestingT, object interface{}, msgAndArgs ...interface{})
+	//	a.NotNil(err)
+	NotNil(object any, messageAndArgs ...any)
 
 	// NotPanics asserts that the code inside the specified PanicTestFunc does NOT panic.
 	//
-	//	assert.NotPanics(t, func(){ RemainCalm() })
-	NotPanics(t TestingT, f assert.PanicTestFunc, msgAndArgs ...interface{})
+	//	a.NotPanics(func(){ RemainCalm() })
+	NotPanics(f assert.PanicTestFunc, messageAndArgs ...any)
 
 	// NotRegexp asserts that a specified regexp does not match a string.
 	//
-	//	assert.NotRegexp(t, regexp.MustCompile("starts"), "it's starting")
-	//	assert.NotRegexp(t, "^start", "it's not starting")
-	NotRegexp(t TestingT, rx interface{}, str interface{}, msgAndArgs ...interface{})
+	//	a.NotRegexp(regexp.MustCompile("starts"), "it's starting")
+	//	a.NotRegexp("^start", "it's not starting")
+	NotRegexp(rx any, str any, messageAndArgs ...any)
 
 	// NotSame asserts that two pointers do not reference the same object.
 	//
-	//	assert.NotSame(t, ptr1, ptr2)
+	//	a.NotSame(ptr1, ptr2)
 	//
 	// Both arguments must be pointer variables. Pointer variable sameness is
 	// determined based on the equality of both type and value.
-	NotSame(t TestingT, expected interface{}, actual interface{}, msgAndArgs ...interface{})
+	NotSame(expected any, actual any, messageAndArgs ...any)
 
 	// NotSubset asserts that the specified list(array, slice...) contains not all
 	// elements given in the specified subset(array, slice...).
 	//
-	//	assert.NotSubset(t, [1, 3, 4], [1, 2], "But [1, 3, 4] does not contain [1, 2]")
-	NotSubset(t TestingT, list interface{}, subset interface{}, msgAndArgs ...interface{})
+	//	a.NotSubset([1, 3, 4], [1, 2], "But [1, 3, 4] does not contain [1, 2]")
+	NotSubset(list any, subset any, messageAndArgs ...any)
 
 	// NotZero asserts that i is not the zero value for its type.
-	NotZero(t TestingT, i interface{}, msgAndArgs ...interface{})
+	NotZero(i any, messageAndArgs ...any)
 
 	// Panics asserts that the code inside the specified PanicTestFunc panics.
 	//
-	//	assert.Panics(t, func(){ GoCrazy() })
-	Panics(t TestingT, f assert.PanicTestFunc, msgAndArgs ...interface{})
+	//	a.Panics(func(){ GoCrazy() })
+	Panics(f assert.PanicTestFunc, messageAndArgs ...any)
 
 	// PanicsWithError asserts that the code inside the specified PanicTestFunc
 	// panics, and that the recovered panic value is an error that satisfies the
 	// EqualError comparison.
 	//
-	//	assert.PanicsWithError(t, "crazy error", func(){ GoCrazy() })
-	PanicsWithError(t TestingT, errString string, f assert.PanicTestFunc, msgAndArgs ...interface{})
+	//	a.PanicsWithError("crazy error", func(){ GoCrazy() })
+	PanicsWithError(errString string, f assert.PanicTestFunc, messageAndArgs ...any)
 
 	// PanicsWithValue asserts that the code inside the specified PanicTestFunc panics, and that
 	// the recovered panic value equals the expected panic value.
 	//
-	//	assert.PanicsWithValue(t, "crazy error", func(){ GoCrazy() })
-	PanicsWithValue(t TestingT, expected interface{}, f assert.PanicTestFunc, msgAndArgs ...interface{})
+	//	a.PanicsWithValue("crazy error", func(){ GoCrazy() })
+	PanicsWithValue(expected any, f assert.PanicTestFunc, messageAndArgs ...any)
 
 	// Positive asserts that the specified element is positive
 	//
-	//	assert.Positive(t, 1)
-	//	assert.Positive(t, 1.23)
-	Positive(t TestingT, e interface{}, msgAndArgs ...interface{})
+	//	a.Positive(1)
+	//	a.Positive(1.23)
+	Positive(e any, messageAndArgs ...any)
 
 	// Regexp asserts that a specified regexp matches a string.
 	//
-	//	assert.Regexp(t, regexp.MustCompile("start"), "it's starting")
-	//	assert.Regexp(t, "start...$", "it's not starting")
-	Regexp(t TestingT, rx interface{}, str interface{}, msgAndArgs ...interface{})
+	//	a.Regexp(regexp.MustCompile("start"), "it's starting")
+	//	a.Regexp("start...$", "it's not starting")
+	Regexp(rx any, str any, messageAndArgs ...any)
 
 	// Same asserts that two pointers reference the same object.
 	//
-	//	assert.Same(t, ptr1, ptr2)
+	//	a.Same(ptr1, ptr2)
 	//
 	// Both arguments must be pointer variables. Pointer variable sameness is
 	// determined based on the equality of both type and value.
-	Same(t TestingT, expected interface{}, actual interface{}, msgAndArgs ...interface{})
+	Same(expected any, actual any, messageAndArgs ...any)
 
 	// Subset asserts that the specified list(array, slice...) contains all
 	// elements given in the specified subset(array, slice...).
 	//
-	//	assert.Subset(t, [1, 2, 3], [1, 2], "But [1, 2, 3] does contain [1, 2]")
-	Subset(t TestingT, list interface{}, subset interface{}, msgAndArgs ...interface{})
+	//	a.Subset([1, 2, 3], [1, 2], "But [1, 2, 3] does contain [1, 2]")
+	Subset(list any, subset any, messageAndArgs ...any)
 
 	// True asserts that the specified value is true.
 	//
-	//	assert.True(t, myBool)
-	True(t TestingT, value bool, msgAndArgs ...interface{})
+	//	a.True(myBool)
+	True(value bool, messageAndArgs ...any)
 
 	// Zero asserts that i is the zero value for its type.
-	Zero(t TestingT, i interface{}, msgAndArgs ...interface{})
+	Zero(i any, messageAndArgs ...any)
 }
@@ -0,0 +1,426 @@
+package muchtest_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/internal"
+	"github.com/grongor/go-muchtest/match"
+	"github.com/grongor/go-muchtest/mocks"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAssertionsSuite(t *testing.T) {
+	muchtest.Run(t, new(AssertionsSuite))
+}
+
+type AssertionsSuite struct {
+	muchtest.Suite
+
+	TestingT *mocks.TestingT
+
+	a *muchtest.Assertions
+}
+
+func (s *AssertionsSuite) BeforeTest(suiteName, testName string) {
+	s.a = muchtest.NewAssertions(s.TestingT)
+
+	s.TestingT.EXPECT().Helper().Maybe()
+}
+
+func (s *AssertionsSuite) TestFailFast() {
+	s.expectErr(`Equal(123): not equal: 456`, func() {
+		s.a.Equal(123, 456)
+		s.a.Equal(123, 789)
+	})
+}
+
+func (s *AssertionsSuite) TestCheck() {
+	s.a.Check(func(a *muchtest.Assertions) {
+		a.Equal(123, 123)
+		a.Len(1, []int{1})
+	})
+
+	s.expectErr("Check(): 1 assertion failed:\n\t1) Len(2): got 1: []int{1}\n", func() {
+		s.a.Check(func(a *muchtest.Assertions) {
+			a.Equal(123, 123)
+			a.Len(2, []int{1})
+		})
+	})
+
+	s.expectErr(
+		"Check(): 3 assertions failed:\n"+
+			"\t1) Contains(\"Such\"): not contained in: \"Much\"\n"+
+			"\t2) Len(2): got 1: []int{1}\n"+
+			"\t   Messages: much 2\n"+
+			"\t3) Greater(5): not greater: 4\n",
+		func() {
+			s.a.Check(func(a *muchtest.Assertions) {
+				a.Contains("Much", "Such")
+				a.Len(2, []int{1}, "much %d", 2)
+				a.Greater(4, 5)
+			})
+		},
+	)
+}
+
+func (s *AssertionsSuite) TestCheck_Nested() {
+	s.expectErr(
+		"Check(): 2 assertions failed:\n"+
+			"\t1) Check(): 1 assertion failed:\n"+
+			"\t   \t1) Nil(): not nil: 1\n"+
+			"\t   \n"+
+			"\t   Messages: inner\n"+
+			"\t2) Zero(): not zero: 2\n",
+		func() {
+			s.a.Check(func(a *muchtest.Assertions) {
+				a.Check(func(a *muchtest.Assertions) {
+					a.Nil(1)
+				}, "inner")
+				a.Zero(2)
+			})
+		},
+	)
+}
+
+func (s *AssertionsSuite) TestCheck_FailNow() {
+	s.expectErr(
+		"Check(): 2 assertions failed:\n"+
+			"\t1) Positive(): not positive: -1\n"+
+			"\t2) stop\n",
+		func() {
+			s.a.Check(func(a *muchtest.Assertions) {
+				a.Positive(-1)
+				a.FailNow("stop")
+				a.Negative(1)
+			})
+		},
+	)
+}
+
+func (s *AssertionsSuite) TestCheck_Goexit() {
+	s.expectErr("Check(): 1 assertion failed:\n\t1) Positive(): not positive: -1\n", func() {
+		s.a.Check(func(a *muchtest.Assertions) {
+			a.Positive(-1)
+			runtime.Goexit()
+		})
+	})
+}
+
+func (s *AssertionsSuite) TestCheck_Panic() {
+	s.expectErrorf("Check(): 2 assertions failed:\n" +
+		"\t1) Positive(): not positive: -1\n" +
+		"\t2) Check(): 1 assertion failed:\n" +
+		"\t   \t1) Zero(): not zero: 1\n")
+
+	s.S.PanicsWithValue("much panic", func() {
+		s.a.Check(func(a *muchtest.Assertions) {
+			a.Positive(-1)
+			a.Check(func(a *muchtest.Assertions) {
+				a.Zero(1)
+				panic("much panic")
+			})
+		})
+	})
+}
+
+func (s *AssertionsSuite) TestTestifyMethods() {
+	err := fmt.Errorf("wrapped: %w", os.ErrNotExist)
+	value := 1
+
+	s.a.Equal([]int{1}, []int{1})
+	s.a.NotEqual(1, 2)
+	s.a.EqualValues(int32(1), int64(1))
+	s.a.EqualValues(struct{ P *int }{}, struct{ P *int }{})
+	s.a.Exactly(int32(1), int32(1))
+	s.a.Same(&value, &value)
+	s.a.Nil(nil)
+	s.a.NotNil(&value)
+	s.a.Empty("")
+	s.a.NotEmpty([]int{1})
+	s.a.Zero(0)
+	s.a.NotZero(1)
+	s.a.True(true)
+	s.a.False(false)
+	s.a.Len(2, map[int]int{1: 1, 2: 2})
+	s.a.Contains("much wow", "wow")
+	s.a.Contains(map[string]int{"much": 1}, "much")
+	s.a.NotContains([]int{1}, 2)
+	s.a.Greater(2, 1)
+	s.a.GreaterOrEqual("b", "b")
+	s.a.Less(1.5, 2.0)
+	s.a.LessOrEqual(1, 1)
+	s.a.Positive(1)
+	s.a.Negative(-1)
+	s.a.IsIncreasing([]int{1, 2, 3})
+	s.a.IsNonIncreasing([]int{2, 2, 1})
+	s.a.IsDecreasing([]string{"b", "a"})
+	s.a.IsNonDecreasing([]float64{1, 1, 2})
+	s.a.InDelta(3.14, 22/7.0, 0.01)
+	s.a.InDeltaSlice([]float64{1, 2}, []float64{1.01, 1.99}, 0.02)
+	s.a.InEpsilon(100, 101, 0.02)
+	s.a.IsType(0, 1)
+	s.a.Implements((*error)(nil), err)
+	s.a.Error(os.ErrNotExist, err)
+	s.a.Error("wrapped", err)
+	s.a.Error(nil, err)
+	s.a.NoError(nil)
+	s.a.EqualError(err, "wrapped: file does not exist")
+	s.a.ErrorContains(err, "does not")
+	s.a.ErrorIs(err, os.ErrNotExist)
+	s.a.NotErrorIs(err, os.ErrExist)
+	s.a.Regexp(`^much`, "much wow")
+	s.a.NotRegexp(`^wow`, "much wow")
+	s.a.Panics(func() { panic("much") })
+	s.a.PanicsWithValue("much", func() { panic("much") })
+	s.a.PanicsWithError("much", func() { panic(errors.New("much")) })
+	s.a.NotPanics(func() {})
+	s.a.DirExists("testdata")
+
+	var pathErr *fs.PathError
+
+	s.a.ErrorAs(&fs.PathError{Op: "open", Err: os.ErrNotExist}, &pathErr)
+
+	s.expectErr(`DirExists("assert.go"): not a directory`, func() {
+		s.a.DirExists("assert.go")
+	})
+
+	s.expectErr(`Greater(2): not greater: 1 Messages: much 1`, func() {
+		s.a.Greater(1, 2, "much %d", 1)
+	})
+}
+
+func (s *AssertionsSuite) TestContains_Nil() {
+	s.expectErr(`Contains(1): not a container: nil`, func() {
+		s.a.Contains(nil, 1)
+	})
+
+	s.expectErr(`Contains(1): not a container: nil`, func() {
+		s.a.Contains((*[]int)(nil), 1)
+	})
+
+	s.expectErr(`NotContains(): not a container: nil`, func() {
+		s.a.NotContains(nil, 1)
+	})
+}
+
+func (s *AssertionsSuite) TestSubset() {
+	s.a.Subset([]int{1, 2, 3}, []int{3, 1})
+	s.a.Subset(map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1})
+	s.a.Subset([]int{1}, nil)
+	s.a.NotSubset([]int{1, 2}, []int{3})
+	s.a.NotSubset(map[string]int{"a": 1, "b": 2}, map[string]int{"a": 2})
+
+	s.expectErr(`All([ContainsKeyValue("a", 1) ContainsKeyValue("b", 3)]): got one: ContainsKeyValue("b", 3): value not matched: 2`, func() {
+		s.a.Subset(map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1, "b": 3})
+	})
+
+	s.expectErr(`All([Contains(1)]): got none: Contains(1): not a container: nil`, func() {
+		s.a.Subset(nil, []int{1})
+	})
+
+	s.expectErr("NotSubset(): not a container: nil", func() {
+		s.a.NotSubset(nil, []int{1})
+	})
+
+	s.expectErr("Subset(): subset must be an array, slice or map, got int", func() {
+		s.a.Subset([]int{1}, 1)
+	})
+
+	s.expectErr("NotSubset(): subset must be an array, slice or map, got string", func() {
+		s.a.NotSubset([]int{1}, "1")
+	})
+}
+
+func (s *AssertionsSuite) TestElementsMatch() {
+	s.a.ElementsMatch([]int{1, 3, 2, 3}, []int{1, 3, 3, 2})
+	s.a.ElementsMatch(nil, nil)
+	s.a.ElementsMatch(nil, []int{})
+	s.a.ElementsMatch([]int{}, nil)
+
+	s.expectErr("ElementsMatch(1): not matched: []any{}", func() {
+		s.a.ElementsMatch([]int{1}, nil)
+	})
+
+	s.expectErr("ElementsMatch(): expected must be an array or slice, got map[string]int", func() {
+		s.a.ElementsMatch(map[string]int{"a": 1}, map[string]int{"a": 1})
+	})
+}
+
+func (s *AssertionsSuite) TestSnapshot() {
+	s.S.Snapshot("string", "much\nwow\n")
+	s.S.Snapshot("value", map[string]any{"such": []int{1, 2}, "test": true})
+
+	s.expectErr(`Snapshot("value"): TestingT doesn't implement Name() string`, func() {
+		s.a.Snapshot("value", 123)
+	})
+}
+
+// TestSnapshot_Skipped owns a snapshot that mustn't be reported as obsolete when the test is skipped.
+func (s *AssertionsSuite) TestSnapshot_Skipped() {
+	s.T().Skip("much skipped")
+
+	s.S.Snapshot("string", "much\nskipped\n")
+}
+
+func (s *AssertionsSuite) TestEventually() {
+	calls := 0
+
+	s.a.Eventually(func() bool {
+		calls++
+
+		return calls == 3
+	}, time.Second, time.Millisecond)
+	s.S.Equal(3, calls)
+
+	s.expectErr("Eventually(): condition not satisfied within 20ms Messages: much 1", func() {
+		s.a.Eventually(func() bool { return false }, 20*time.Millisecond, time.Millisecond, "much %d", 1)
+	})
+
+	s.expectErr("Eventually(): tick must be positive, got 0s", func() {
+		s.a.Eventually(func() bool { return true }, time.Second, 0)
+	})
+}
+
+func (s *AssertionsSuite) TestEventuallyMatch() {
+	calls := 0
+	counter := func() int {
+		calls++
+
+		return calls
+	}
+
+	s.a.EventuallyMatch(counter, 3, muchtest.Tick(time.Millisecond))
+	s.S.Equal(3, calls)
+
+	s.a.EventuallyMatch(func() string { return "Much" }, match.Prefix("M"))
+
+	calls = 0
+	clock := clockwork.NewFakeClock()
+	start := clock.Now()
+
+	s.expectErr(
+		"EventuallyMatch(): not matched within 1s (11 polls): Greater(20): not greater: 11",
+		func() {
+			s.a.EventuallyMatch(counter, match.Greater(20), muchtest.AdvanceClock(clock), muchtest.Tick(100*time.Millisecond))
+		},
+	)
+	s.S.Equal(time.Second, clock.Since(start))
+
+	s.expectErr("EventuallyMatch(): fn must be a func() T, got int", func() {
+		s.a.EventuallyMatch(1, 1)
+	})
+
+	s.expectErr("EventuallyMatch(): tick must be positive, got 0s", func() {
+		s.a.EventuallyMatch(counter, 1, muchtest.AdvanceClock(clock), muchtest.Tick(0))
+	})
+
+	s.expectErr("EventuallyMatch(): tick must be positive, got -1s", func() {
+		s.a.EventuallyMatch(counter, 1, muchtest.Tick(-time.Second))
+	})
+
+	s.expectErr("EventuallyMatch(): waitFor must be positive, got 0s", func() {
+		s.a.EventuallyMatch(counter, 1, muchtest.WaitFor(0))
+	})
+
+	s.expectErr("EventuallyMatch(): not matched within 10ms (1 poll): Equal(2): not equal: 1 Messages: much 1", func() {
+		s.a.EventuallyMatch(func() int { return 1 }, 2, muchtest.WaitFor(10*time.Millisecond),
+			muchtest.Tick(time.Second), "much %d", 1)
+	})
+}
+
+func (s *AssertionsSuite) TestEventuallyMatch_AdvanceClock() {
+	clock := clockwork.NewFakeClock()
+	done := make(chan bool)
+
+	go func() {
+		defer close(done)
+
+		<-clock.After(time.Minute)
+	}()
+
+	isDone := func() bool {
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	}
+
+	s.a.EventuallyMatch(isDone, true, muchtest.AdvanceClock(clock), muchtest.WaitFor(time.Hour), muchtest.Tick(time.Second))
+}
+
+func (s *AssertionsSuite) TestConsistently() {
+	clock := clockwork.NewFakeClock()
+	start := clock.Now()
+
+	s.a.Consistently(func() time.Duration { return clock.Since(start) }, match.LessOrEqual(time.Second),
+		muchtest.AdvanceClock(clock), muchtest.Tick(250*time.Millisecond))
+	s.S.Equal(time.Second, clock.Since(start))
+
+	s.expectErr(
+		"Consistently(): not matched after 750ms (4 polls): "+
+			"LessOrEqual(time.Duration(500ms)): not less or equal: time.Duration(750ms)",
+		func() {
+			s.a.Consistently(func() time.Duration { return clock.Since(start) - time.Second },
+				match.LessOrEqual(500*time.Millisecond), muchtest.AdvanceClock(clock), muchtest.Tick(250*time.Millisecond))
+		},
+	)
+}
+
+func (s *AssertionsSuite) TestNever() {
+	s.a.Never(func() bool { return false }, 20*time.Millisecond, time.Millisecond)
+
+	s.expectErr("Never(): condition satisfied within 1s", func() {
+		s.a.Never(func() bool { return true }, time.Second, time.Millisecond)
+	})
+
+	s.expectErr("Never(): waitFor must be positive, got -1s", func() {
+		s.a.Never(func() bool { return false }, -time.Second, time.Millisecond)
+	})
+}
+
+func (s *AssertionsSuite) expectErr(desc string, fn func()) {
+	s.T().Helper()
+
+	s.expectErrorf(desc)
+	s.TestingT.EXPECT().FailNow().Once().Run(func(mock.Arguments) { runtime.Goexit() })
+
+	done := make(chan bool)
+
+	go func() {
+		defer close(done)
+
+		fn()
+
+		done <- true
+	}()
+
+	if notExited := <-done; notExited {
+		s.Fail("expected goroutine to exit")
+	}
+}
+
+func (s *AssertionsSuite) expectErrorf(desc string) {
+	s.T().Helper()
+
+	s.TestingT.EXPECT().Errorf("\n%s", mock.MatchedBy(func(actual string) bool {
+		tokens := strings.Fields(desc)
+		for i, token := range tokens {
+			tokens[i] = regexp.QuoteMeta(token)
+		}
+
+		return regexp.MustCompile(`(?s)\s*Error Trace:(.*?)Error:\s+` + strings.Join(tokens, `\s+`)).
+			MatchString(internal.TrimDiff(actual))
+	})).Once()
+}
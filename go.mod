@@ -1,10 +1,11 @@
 module github.com/grongor/go-muchtest
 
-go 1.19
+go 1.21
 
 require (
 	github.com/google/go-cmp v0.5.9
 	github.com/jonboulle/clockwork v0.3.0
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/stretchr/testify v1.8.1
 	go.uber.org/zap v1.24.0
 	golang.org/x/text v0.5.0
@@ -12,7 +13,6 @@ require (
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/objx v0.5.0 // indirect
 	go.uber.org/atomic v1.10.0 // indirect
 	go.uber.org/multierr v1.8.0 // indirect
@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+const SnapshotsDir = "testdata/__snapshots__"
+
+var updateSnapshots = flag.Bool("muchtest.update", false, "create or rewrite snapshot files with actual values")
+
+var usedSnapshots = struct {
+	sync.Mutex
+	paths map[string]bool
+}{paths: map[string]bool{}}
+
+func UpdateSnapshots() bool {
+	if *updateSnapshots {
+		return true
+	}
+
+	if update := flag.Lookup("update"); update != nil && update.Value.String() == "true" {
+		return true
+	}
+
+	update, _ := strconv.ParseBool(os.Getenv("MUCHTEST_UPDATE_SNAPSHOTS"))
+
+	return update
+}
+
+func UseSnapshot(path string) {
+	usedSnapshots.Lock()
+	defer usedSnapshots.Unlock()
+
+	usedSnapshots.paths[filepath.Clean(path)] = true
+}
+
+func SnapshotUsed(path string) bool {
+	usedSnapshots.Lock()
+	defer usedSnapshots.Unlock()
+
+	return usedSnapshots.paths[filepath.Clean(path)]
+}
@@ -0,0 +1,325 @@
+package muchtest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// LogLevel is the level of a LogRecord; it has the same values as the corresponding zap levels.
+type LogLevel int8
+
+const (
+	LogDebug LogLevel = iota - 1
+	LogInfo
+	LogWarn
+	LogError
+	LogDPanic
+	LogPanic
+	LogFatal
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "debug"
+	case LogInfo:
+		return "info"
+	case LogWarn:
+		return "warn"
+	case LogError:
+		return "error"
+	case LogDPanic:
+		return "dpanic"
+	case LogPanic:
+		return "panic"
+	case LogFatal:
+		return "fatal"
+	default:
+		return fmt.Sprintf("LogLevel(%d)", l)
+	}
+}
+
+func (l LogLevel) title() string {
+	return cases.Title(language.English, cases.NoLower).String(l.String())
+}
+
+// LogRecord is a single logged entry, whichever logger logged it. Zap and Slog assert the records kept by their
+// logCore, adapters of other logging libraries record them via a LogRecorder.
+type LogRecord struct {
+	Time       time.Time
+	Level      LogLevel
+	LoggerName string
+	Message    string
+	CallerFile string
+	CallerLine int
+	Stack      string
+	Attrs      []LogAttr
+
+	// with and call split the Attrs into the ones inherited from the logger and the ones added at the call-site, so
+	// that they may be asserted separately, see With().
+	with, call []LogAttr
+	// source is what the logger logged, used to encode the record, see Encoded().
+	source any
+}
+
+func (r LogRecord) ctxMap() map[string]any {
+	return logAttrsMap(r.Attrs)
+}
+
+func (r LogRecord) withMap() map[string]any {
+	return logAttrsMap(r.with)
+}
+
+func (r LogRecord) callMap() map[string]any {
+	if r.with == nil && r.call == nil {
+		return r.ctxMap()
+	}
+
+	return logAttrsMap(r.call)
+}
+
+// trimmedCaller returns the caller as zap prints it: the package directory, the file and the line.
+func (r LogRecord) trimmedCaller() string {
+	file := r.CallerFile
+
+	if i := strings.LastIndexByte(file, '/'); i != -1 {
+		if j := strings.LastIndexByte(file[:i], '/'); j != -1 {
+			file = file[j+1:]
+		}
+	}
+
+	return file + ":" + strconv.Itoa(r.CallerLine)
+}
+
+// LogAttr is a single context value of a LogRecord. Groups are represented by a Value of type []LogAttr.
+type LogAttr struct {
+	Key   string
+	Value any
+}
+
+// logAttrsMap returns the attrs as the ctx map the assertions match: groups become nested maps, merged with the
+// groups of the same name, inline groups (with an empty key) are merged into their parent and empty groups are left
+// out. Errors are kept as their messages, like zap keeps them.
+func logAttrsMap(attrs []LogAttr) map[string]any {
+	ctxMap := map[string]any{}
+
+	logAttrsInto(ctxMap, attrs)
+
+	return ctxMap
+}
+
+func logAttrsInto(ctxMap map[string]any, attrs []LogAttr) {
+	for _, attr := range attrs {
+		group, isGroup := attr.Value.([]LogAttr)
+
+		switch {
+		case isGroup && len(group) == 0:
+		case isGroup && attr.Key == "":
+			logAttrsInto(ctxMap, group)
+		case isGroup:
+			groupMap, ok := ctxMap[attr.Key].(map[string]any)
+			if !ok {
+				groupMap = map[string]any{}
+				ctxMap[attr.Key] = groupMap
+			}
+
+			logAttrsInto(groupMap, group)
+		case attr.Key != "":
+			if err, isErr := attr.Value.(error); isErr {
+				ctxMap[attr.Key] = err.Error()
+			} else {
+				ctxMap[attr.Key] = attr.Value
+			}
+		}
+	}
+}
+
+// logMapAttrs returns the attrs of a ctx map, such as the ones zap encodes, sorted by their keys.
+func logMapAttrs(ctxMap map[string]any) []LogAttr {
+	attrs := make([]LogAttr, 0, len(ctxMap))
+
+	for key, value := range ctxMap {
+		attrs = append(attrs, LogAttr{Key: key, Value: value})
+	}
+
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Key < attrs[j].Key
+	})
+
+	return attrs
+}
+
+// logStore keeps the records, in the order they were logged.
+type logStore struct {
+	mu      sync.Mutex
+	records []LogRecord
+
+	// pull, when set, appends the records logged elsewhere since the last call, see Zap.Use().
+	pull func(records []LogRecord) []LogRecord
+}
+
+func (s *logStore) add(record LogRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = append(s.records, record)
+}
+
+func (s *logStore) all() []LogRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pull != nil {
+		s.records = s.pull(s.records)
+	}
+
+	return s.records[:len(s.records):len(s.records)]
+}
+
+// LogRecorder lets adapters of other logging libraries record entries into a Zap, where they're asserted like any
+// zap entry.
+//
+// Recording an entry never panics nor exits, not even at LogPanic or LogFatal; that's left to the adapter.
+type LogRecorder interface {
+	Enabled(level LogLevel) bool
+	Record(record LogRecord) error
+	With(attrs []LogAttr) LogRecorder
+	WithGroup(name string) LogRecorder
+}
+
+type LogAdapter func(recorder LogRecorder) any
+
+var logAdapters = struct {
+	sync.RWMutex
+	adapters map[string]LogAdapter
+}{adapters: map[string]LogAdapter{}}
+
+func RegisterLogAdapter(name string, adapter LogAdapter) {
+	logAdapters.Lock()
+	defer logAdapters.Unlock()
+
+	if _, exists := logAdapters.adapters[name]; exists {
+		panic(fmt.Sprintf("muchtest.RegisterLogAdapter(): adapter %q is already registered", name))
+	}
+
+	logAdapters.adapters[name] = adapter
+}
+
+func (z *Zap) Recorder() LogRecorder {
+	z.t.Helper()
+
+	if z.core.state == logCreated {
+		z.Get()
+	}
+
+	if z.used {
+		z.core.reportError("Recorder() is only available with Get() or GetAt(), not with Use()")
+	}
+
+	return logRecorder{store: z.core.store, level: z.level}
+}
+
+func (z *Zap) Adapt(name string) any {
+	z.t.Helper()
+
+	return z.adapt(name, z.Recorder())
+}
+
+func (z *Zap) adapt(name string, recorder LogRecorder) any {
+	z.t.Helper()
+
+	logAdapters.RLock()
+	adapter, ok := logAdapters.adapters[name]
+	logAdapters.RUnlock()
+
+	if !ok {
+		z.core.reportError(fmt.Sprintf("Adapt(%q): no such adapter, registered: %s", name,
+			strings.Join(logAdapterNames(), ", ")))
+	}
+
+	return adapter(recorder)
+}
+
+func logAdapterNames() []string {
+	logAdapters.RLock()
+	defer logAdapters.RUnlock()
+
+	names := make([]string, 0, len(logAdapters.adapters))
+	for name := range logAdapters.adapters {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// logRecorder records into the store; the attrs of With() and the groups of WithGroup() apply to the records that
+// follow, like in slog.
+type logRecorder struct {
+	store  *logStore
+	level  LogLevel
+	with   []LogAttr
+	groups []string
+}
+
+func (r logRecorder) Enabled(level LogLevel) bool {
+	return level >= r.level
+}
+
+func (r logRecorder) Record(record LogRecord) error {
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+
+	record.with = r.with
+	record.call = r.grouped(record.Attrs)
+	record.Attrs = append(r.with[:len(r.with):len(r.with)], record.call...)
+
+	r.store.add(record)
+
+	return nil
+}
+
+// increaseLevel drops the entries below the level; a level below the observed one has no effect.
+func (r logRecorder) increaseLevel(level LogLevel) logRecorder {
+	r.level = max(r.level, level)
+
+	return r
+}
+
+func (r logRecorder) With(attrs []LogAttr) LogRecorder {
+	r.with = append(r.with[:len(r.with):len(r.with)], r.grouped(attrs)...)
+
+	return r
+}
+
+func (r logRecorder) WithGroup(name string) LogRecorder {
+	if name == "" {
+		return r
+	}
+
+	r.groups = append(r.groups[:len(r.groups):len(r.groups)], name)
+
+	return r
+}
+
+// grouped nests the attrs into the groups opened by WithGroup().
+func (r logRecorder) grouped(attrs []LogAttr) []LogAttr {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	for i := len(r.groups) - 1; i >= 0; i-- {
+		attrs = []LogAttr{{Key: r.groups[i], Value: attrs}}
+	}
+
+	return attrs
+}
@@ -0,0 +1,345 @@
+package muchtest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grongor/go-muchtest/match"
+)
+
+const logEntryAssertTerminatingMethods = "Ctx, NoCtx, IgnoreCtx, CtxErr and IgnoreMissingCtx"
+
+// logEntryAssert collects the expectations of a single record; Zap and Slog wrap it with the methods of their
+// libraries.
+type logEntryAssert struct {
+	c             *logCore
+	name          *string
+	level         *LogLevel
+	message       any
+	caller        any
+	stack         any
+	time          any
+	with          []any
+	withSet       bool
+	ctxKeys       []string
+	partial, done bool
+}
+
+func (a *logEntryAssert) setCaller(caller any) {
+	a.caller = caller
+}
+
+func (a *logEntryAssert) setStack(stack any) {
+	a.stack = stack
+}
+
+func (a *logEntryAssert) setTime(time any) {
+	a.time = time
+}
+
+func (a *logEntryAssert) setWith(ctxKeyAndValues []any) {
+	a.with = ctxKeyAndValues
+	a.withSet = true
+}
+
+func (a *logEntryAssert) ignoreMissingCtx(ctxKeyAndValues []any) {
+	a.partial = true
+
+	a.ctx(ctxKeyAndValues)
+}
+
+func (a *logEntryAssert) ctx(ctxKeyAndValues []any) {
+	a.ctxKeys = logCtxKeys(ctxKeyAndValues)
+
+	a.assert(a.ctxMatcher(ctxKeyAndValues))
+}
+
+func (a *logEntryAssert) noCtx() {
+	a.ctx([]any{match.Len(0)})
+}
+
+func (a *logEntryAssert) ignoreCtx() {
+	a.assert(nil)
+}
+
+func (a *logEntryAssert) ctxMatcher(ctxKeyAndValues []any) match.Matcher {
+	if len(ctxKeyAndValues) == 1 {
+		if matcher, ok := ctxKeyAndValues[0].(match.Matcher); ok {
+			return matcher
+		}
+	}
+
+	return match.Fn(func(ctxMap map[string]any) (bool, string) {
+		pairs, ok := logCtxPairs(ctxKeyAndValues)
+		if !ok {
+			return false, "Ctx(): ctxKeyAndValues must be a matcher, or (possible) combination of zapcore.Field(s) " +
+				`and/or pairs like: "duration", 5*time.Second, "status", "ok"`
+		}
+
+		if !a.partial && !a.c.partial {
+			roots := map[any]bool{}
+
+			for _, pair := range pairs {
+				root := pair.key
+				if path, isPath := logCtxPath(ctxMap, pair.key); isPath {
+					root = path[0]
+				}
+
+				roots[root] = true
+			}
+
+			if ok, desc := match.Len(len(roots)).Matches(ctxMap); !ok {
+				return ok, desc
+			}
+		}
+
+		for _, pair := range pairs {
+			path, isPath := logCtxPath(ctxMap, pair.key)
+			if !isPath {
+				if ok, desc := match.ContainsKeyValue(pair.key, pair.value).Matches(ctxMap); !ok {
+					return ok, desc
+				}
+
+				continue
+			}
+
+			value, found := logCtxLookup(ctxMap, path)
+			if !found {
+				return false, fmt.Sprintf("Ctx(%q): path not found", pair.key)
+			}
+
+			if ok, desc := match.ToMatcher(pair.value).Matches(value); !ok {
+				return ok, fmt.Sprintf("Ctx(%q): %s", pair.key, desc)
+			}
+		}
+
+		return true, ""
+	})
+}
+
+type logCtxPair struct {
+	key, value any
+}
+
+func logCtxPairs(ctxKeyAndValues []any) ([]logCtxPair, bool) {
+	if len(ctxKeyAndValues)%2 != 0 {
+		return nil, false
+	}
+
+	pairs := make([]logCtxPair, 0, len(ctxKeyAndValues)/2)
+
+	for i := 1; i < len(ctxKeyAndValues); i += 2 {
+		pairs = append(pairs, logCtxPair{key: ctxKeyAndValues[i-1], value: ctxKeyAndValues[i]})
+	}
+
+	return pairs, true
+}
+
+func logCtxPath(ctxMap map[string]any, key any) ([]string, bool) {
+	path, ok := key.(string)
+	if !ok || !strings.Contains(path, ".") {
+		return nil, false
+	}
+
+	if _, exists := ctxMap[path]; exists {
+		return nil, false
+	}
+
+	return strings.Split(path, "."), true
+}
+
+func logCtxLookup(ctxMap map[string]any, path []string) (any, bool) {
+	var current any = ctxMap
+
+	for _, segment := range path {
+		switch value := current.(type) {
+		case map[string]any:
+			var ok bool
+			if current, ok = value[segment]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(value) {
+				return nil, false
+			}
+
+			current = value[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func (a *logEntryAssert) String() string {
+	builder := strings.Builder{}
+
+	if a.name != nil {
+		builder.WriteString(`Assert("`)
+		builder.WriteString(*a.name)
+		builder.WriteString(`")`)
+
+		if a.level == nil && a.message == nil {
+			return builder.String()
+		}
+
+		builder.WriteByte('.')
+	}
+
+	writeMessage := func() {
+		if msg, ok := a.message.(string); ok {
+			builder.WriteByte('"')
+			builder.WriteString(msg)
+			builder.WriteByte('"')
+		} else {
+			fmt.Fprintf(&builder, "%v", a.message)
+		}
+	}
+
+	if a.level != nil {
+		builder.WriteString(a.level.title())
+		builder.WriteByte('(')
+
+		if a.message != nil {
+			writeMessage()
+		}
+
+		builder.WriteByte(')')
+	} else if a.message != nil {
+		builder.WriteString("Msg(")
+		writeMessage()
+		builder.WriteByte(')')
+	}
+
+	return builder.String()
+}
+
+func (a *logEntryAssert) setLevelAndMessage(level LogLevel, message []any) {
+	if a.level != nil {
+		a.c.reportError(fmt.Sprintf("Can't call %s(): %s() already called", level.title(), a.level.title()))
+	}
+
+	a.level = &level
+
+	if len(message) != 0 {
+		if len(message) > 1 || message[0] == nil {
+			const message = "Message parameter to %s() must be a single string or matcher, or nothing"
+			a.c.reportError(fmt.Sprintf(message, level.title()))
+		}
+
+		a.setMessage(message[0])
+	}
+}
+
+func (a *logEntryAssert) setMessage(message any) {
+	if a.message != nil {
+		if a.level != nil {
+			a.c.reportError(fmt.Sprintf("Msg() can't be called after %s()", a.level.title()))
+		}
+
+		a.c.reportError("Msg() can't be called multiple times")
+	}
+
+	a.message = message
+}
+
+func (a *logEntryAssert) assert(ctxMatcher match.Matcher) {
+	if a.done {
+		a.c.reportError("Only one of these methods can be called (once): " + logEntryAssertTerminatingMethods)
+	}
+
+	a.done = true
+	a.c.pending = nil
+
+	a.c.assertRecord(a.checks(a.name, a.level, a.message, ctxMatcher))
+}
+
+func (a *logEntryAssert) checkRecord(
+	record LogRecord,
+	name *string,
+	level *LogLevel,
+	message any,
+	ctxMatcher match.Matcher,
+) (bool, string) {
+	return logCheckFields(a.checks(name, level, message, ctxMatcher), record)
+}
+
+func (a *logEntryAssert) checks(
+	name *string,
+	level *LogLevel,
+	message any,
+	ctxMatcher match.Matcher,
+) []logFieldCheck {
+	checks := logBaseChecks(name, level, message)
+
+	if a.caller != nil {
+		checks = append(checks, logFieldCheck{
+			field: "caller",
+			check: func(record LogRecord) (bool, string) {
+				if record.CallerFile == "" {
+					return false, "Caller(): entry has no caller" + a.c.callerHint
+				}
+
+				if ok, desc := match.ToMatcher(a.caller).Matches(record.CallerFile); !ok {
+					return ok, "Caller(): " + desc
+				}
+
+				return true, ""
+			},
+		})
+	}
+
+	if a.stack != nil {
+		checks = append(checks, logFieldCheck{
+			field: "stack",
+			check: func(record LogRecord) (bool, string) {
+				if ok, desc := match.ToMatcher(a.stack).Matches(record.Stack); !ok {
+					return ok, "Stack(): " + desc
+				}
+
+				return true, ""
+			},
+		})
+	}
+
+	if a.time != nil {
+		checks = append(checks, logFieldCheck{
+			field: "time",
+			check: func(record LogRecord) (bool, string) {
+				if ok, desc := match.ToMatcher(a.time).Matches(record.Time); !ok {
+					return ok, "Time(): " + desc
+				}
+
+				return true, ""
+			},
+		})
+	}
+
+	ctxMap := LogRecord.ctxMap
+
+	if a.withSet {
+		withMatcher := a.ctxMatcher(a.with)
+
+		checks = append(checks, logFieldCheck{
+			field: "with",
+			check: func(record LogRecord) (bool, string) {
+				if ok, desc := withMatcher.Matches(record.withMap()); !ok {
+					return ok, "With(): " + strings.TrimPrefix(desc, "Fn(): ")
+				}
+
+				return true, ""
+			},
+		})
+
+		ctxMap = LogRecord.callMap
+	}
+
+	if ctxMatcher != nil {
+		checks = append(checks, logCtxCheck(ctxMatcher, a.ctxKeys, ctxMap))
+	}
+
+	return checks
+}
@@ -0,0 +1,635 @@
+package muchtest
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grongor/go-muchtest/match"
+	"github.com/stretchr/testify/require"
+)
+
+type logState int
+
+const (
+	logCreated = logState(iota)
+	logInitialized
+	logAsserting
+	logAsserted
+)
+
+// logCore asserts the records of a single logger, whichever library logged them: it owns the cursor, the ignore rules,
+// the Named() scopes and the teardown check. Zap and Slog are its adapters, they translate the levels and the ctx of
+// their libraries and create the loggers that fill the store.
+type logCore struct {
+	t                         TestingT
+	state                     logState
+	store                     *logStore
+	partial, orderless, loose bool
+
+	index       int
+	skip        int
+	usedIndexes []int
+	pending     *logEntryAssert
+	within      []PollOption
+	ignores     []logIgnore
+
+	logPrefix string
+	// callerHint tells how to make the logger record the caller, when it doesn't.
+	callerHint string
+	scope      string
+	parent     *logCore
+	scopes     []*logCore
+}
+
+func newLogCore(t TestingT, logPrefix string) *logCore {
+	return &logCore{t: t, logPrefix: logPrefix}
+}
+
+// initialize starts recording into the store, once the logger is created.
+func (c *logCore) initialize(store *logStore) {
+	c.transition(logInitialized)
+
+	c.store = store
+}
+
+// checkNotCreated reports the configuration methods called once the logger is already created.
+func (c *logCore) checkNotCreated(suite bool, method string) {
+	if c.state == logCreated {
+		return
+	}
+
+	message := method + "() must be called before Get() or GetAt()"
+	if suite {
+		message += ", the suite creates the logger for its fields before the test, call it in SetupLoggers()"
+	}
+
+	c.reportError(message)
+}
+
+func (c *logCore) newAssert(name *string) *logEntryAssert {
+	c.transition(logAsserting)
+	c.checkPendingAssert()
+
+	c.pending = &logEntryAssert{c: c, name: name}
+
+	return c.pending
+}
+
+func (c *logCore) checkPendingAssert() {
+	if c.pending == nil {
+		return
+	}
+
+	const message = "First call one of the terminating methods(%s) on the previous assert: %s"
+	c.reportError(fmt.Sprintf(message, logEntryAssertTerminatingMethods, c.pending.String()))
+}
+
+func (c *logCore) skipNext(n []int) {
+	c.transition(logAsserting)
+
+	if l := len(n); l > 0 {
+		if l != 1 || n[0] < 1 {
+			c.reportError("Parameter to SkipNext() must be a single positive integer, or nothing (equivalent to 1)")
+		}
+
+		c.skip += n[0]
+	} else {
+		c.skip++
+	}
+}
+
+func (c *logCore) assertNoNextEntry() {
+	c.transition(logAsserting)
+	c.consumeSkipped()
+
+	if message := c.checkNoNextEntry(); message != "" {
+		c.reportError(message)
+	}
+}
+
+func (c *logCore) checkNoNextEntry() string {
+	builder := &strings.Builder{}
+
+	for _, scope := range c.scopes {
+		if !scope.loose {
+			builder.WriteString(scope.checkNoNextEntry())
+		}
+	}
+
+	indexes := c.nextIndexes()
+	if len(indexes) == 0 {
+		return builder.String()
+	}
+
+	builder.WriteString(c.scopeString())
+
+	_, _ = fmt.Fprintf(builder, "AssertNoNextEntry(): there are entries available (%d)%s\n", len(indexes), c.ignoredNote())
+
+	all := c.store.all()
+
+	for _, i := range indexes {
+		builder.WriteByte('\t')
+		formatLogRecord(builder, all[i])
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
+
+// checkTeardown returns the failures of what's left unasserted when the test ends.
+func (c *logCore) checkTeardown() []string {
+	if c.store == nil {
+		return nil
+	}
+
+	var failures []string
+
+	if c.pending != nil {
+		failures = append(failures, fmt.Sprintf("%sMissing call to one of the terminating methods(%s) on assert: %s",
+			c.logPrefix, logEntryAssertTerminatingMethods, c.pending.String()))
+	}
+
+	if !c.loose {
+		if message := c.checkNoNextEntry(); message != "" {
+			failures = append(failures, c.logPrefix+message)
+		}
+	}
+
+	return failures
+}
+
+type logIgnore struct {
+	level           LogLevel
+	message         any
+	ctxKeyAndValues []any
+}
+
+func (c *logCore) ignore(level LogLevel, message any, ctxKeyAndValues []any) {
+	c.ignores = append(c.ignores, logIgnore{level: level, message: message, ctxKeyAndValues: ctxKeyAndValues})
+}
+
+func (c *logCore) isIgnored(record LogRecord) bool {
+	for _, ignore := range c.ignores {
+		a := &logEntryAssert{c: c, partial: true}
+
+		var ctxMatcher match.Matcher
+		if len(ignore.ctxKeyAndValues) != 0 {
+			ctxMatcher = a.ctxMatcher(ignore.ctxKeyAndValues)
+		}
+
+		if ok, _ := a.checkRecord(record, nil, &ignore.level, ignore.message, ctxMatcher); ok {
+			return true
+		}
+	}
+
+	return c.parent != nil && c.parent.isIgnored(record)
+}
+
+func (c *logCore) hasIgnores() bool {
+	return len(c.ignores) != 0 || c.parent != nil && c.parent.hasIgnores()
+}
+
+func (c *logCore) records() []LogRecord {
+	all := c.store.all()
+	if len(c.ignores) == 0 && c.scope == "" && len(c.scopes) == 0 {
+		return all
+	}
+
+	records := make([]LogRecord, 0, len(all))
+
+	for _, record := range all {
+		if c.inScope(record) && !c.isIgnored(record) {
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
+// nextIndexes returns the indexes (into all the stored records) of the records that are yet to be asserted, leaving
+// out the ones to be skipped by SkipNext(). The cursor points into all the stored records too, so that Ignore() and
+// Named() don't move it.
+func (c *logCore) nextIndexes() []int {
+	all := c.store.all()
+	skip := c.skip
+
+	var indexes []int
+
+	for i := c.index; i < len(all); i++ {
+		if !c.isNext(i, all[i]) {
+			continue
+		}
+
+		if skip != 0 {
+			skip--
+
+			continue
+		}
+
+		indexes = append(indexes, i)
+	}
+
+	return indexes
+}
+
+// consumeSkipped moves the cursor past the records skipped by SkipNext(); the records may be logged only after the
+// call, so it's done right before the records are consumed by an assertion.
+func (c *logCore) consumeSkipped() {
+	all := c.store.all()
+
+	for i := c.index; i < len(all) && c.skip != 0; i++ {
+		if c.isNext(i, all[i]) {
+			c.skip--
+			c.index = i + 1
+		}
+	}
+}
+
+func (c *logCore) isNext(i int, record LogRecord) bool {
+	return !c.isUsed(i) && c.inScope(record) && !c.isIgnored(record)
+}
+
+func (c *logCore) isUsed(i int) bool {
+	for _, j := range c.usedIndexes {
+		if i == j {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *logCore) ignoredNote() string {
+	if !c.hasIgnores() {
+		return ""
+	}
+
+	ignored := 0
+
+	for _, record := range c.store.all() {
+		if c.inScope(record) && c.isIgnored(record) {
+			ignored++
+		}
+	}
+
+	switch ignored {
+	case 0:
+		return ""
+	case 1:
+		return " (1 entry ignored)"
+	default:
+		return fmt.Sprintf(" (%d entries ignored)", ignored)
+	}
+}
+
+func formatLogRecord(builder *strings.Builder, record LogRecord) {
+	builder.WriteByte('[')
+	builder.WriteString(record.Time.UTC().Format("2006-01-02 15:04:05.999999"))
+	builder.WriteString("] ")
+	builder.WriteString(strings.ToUpper(record.Level.String()))
+	builder.WriteString(" ")
+
+	if record.LoggerName != "" {
+		builder.WriteByte('[')
+		builder.WriteString(record.LoggerName)
+		builder.WriteString("] ")
+	}
+
+	if record.CallerFile != "" {
+		builder.WriteString(record.trimmedCaller())
+		builder.WriteByte(' ')
+	}
+
+	builder.WriteString(record.Message)
+
+	ctxMap := record.ctxMap()
+	if len(ctxMap) != 0 {
+		keys := make([]string, 0, len(ctxMap))
+		for k := range ctxMap {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		builder.WriteString("\n\t\t[")
+
+		for i, k := range keys {
+			if i != 0 {
+				builder.WriteString(", ")
+			}
+
+			fmt.Fprintf(builder, `"%s": "%v"`, k, ctxMap[k])
+		}
+
+		builder.WriteByte(']')
+	}
+
+	if record.Stack != "" {
+		builder.WriteString("\n\t\tStack:\n\t\t\t")
+		builder.WriteString(strings.ReplaceAll(strings.TrimSuffix(record.Stack, "\n"), "\n", "\n\t\t\t"))
+	}
+}
+
+func (c *logCore) ignoreMissingContext(partial []bool) {
+	c.partial = c.getBool(partial, "IgnoreMissingContext")
+}
+
+func (c *logCore) setOrderless(orderless []bool) {
+	newOrderless := c.getBool(orderless, "Orderless")
+
+	if c.orderless {
+		if newOrderless {
+			c.reportError("Orderless(): already set to orderless")
+		}
+
+		if len(c.usedIndexes) != 0 {
+			c.consumeSkipped()
+
+			remaining := c.nextIndexes()
+			c.index = len(c.store.all())
+			c.usedIndexes = nil
+
+			if len(remaining) != 0 {
+				c.reportError("Orderless(): some messages weren't asserted")
+			}
+		}
+	} else if !newOrderless {
+		c.reportError("Orderless(): already set to ordered")
+	}
+
+	c.orderless = newOrderless
+}
+
+func (c *logCore) setWithin(waitFor time.Duration, options []PollOption) {
+	c.within = append([]PollOption{WaitFor(waitFor)}, options...)
+}
+
+func (c *logCore) await(condition func() bool) {
+	if c.within == nil {
+		return
+	}
+
+	options := c.within
+	c.within = nil
+
+	p, err := newPoller(condition, match.Equal(true), options)
+	if err != "" {
+		c.reportError("Within(): " + err)
+
+		return
+	}
+
+	if ok, _ := p.poll(true); ok || c.loose {
+		return
+	}
+
+	builder := &strings.Builder{}
+	records := c.records()
+
+	fmt.Fprintf(builder, "Within(%s): expected entry wasn't logged in time (%s)", p.waitFor, p.pollsString())
+
+	if len(records) == 0 {
+		builder.WriteString(", no entries were logged" + c.ignoredNote())
+	} else {
+		fmt.Fprintf(builder, ", entries logged so far (%d)%s:\n", len(records), c.ignoredNote())
+
+		for _, record := range records {
+			builder.WriteByte('\t')
+			formatLogRecord(builder, record)
+			builder.WriteByte('\n')
+		}
+	}
+
+	c.reportError(builder.String())
+}
+
+func (c *logCore) transition(state logState) {
+	if c.state == logCreated && state != logInitialized {
+		c.reportError("First call Get(), GetAt() or Use() methods")
+	}
+
+	if c.state >= logInitialized && state == logInitialized {
+		c.reportError("You can't call Get(), GetAt() or Use() multiple times")
+	}
+
+	if c.state == logAsserting && state == logAsserted || c.state == logAsserted && state == logAsserting {
+		c.reportError("You can't combine ObservedLogs() and AssertNext*() methods")
+	}
+
+	if c.state == logAsserted && state == logAsserted {
+		c.reportError("You can't call ObservedLogs() multiple times")
+	}
+
+	c.state = state
+}
+
+func (c *logCore) assertNext(name *string, level LogLevel, message any, ctxKeyAndValues []any) {
+	if len(ctxKeyAndValues) == 1 {
+		if matcher, ok := ctxKeyAndValues[0].(match.Matcher); ok {
+			c.assert(name, level, message, nil, matcher)
+
+			return
+		}
+	}
+
+	c.assert(name, level, message, logCtxKeys(ctxKeyAndValues), match.Fn(func(ctxMap map[string]any) (bool, string) {
+		if len(ctxKeyAndValues)%2 != 0 {
+			n := "AssertNext"
+			if name != nil {
+				n = "AssertNamed"
+			}
+
+			return false, n + `(): ctxKeyAndValues must be pairs; eg.: "duration", 5*time.Second, "status", "ok"`
+		}
+
+		if !c.partial {
+			if ok, desc := match.Len(len(ctxKeyAndValues) / 2).Matches(ctxMap); !ok {
+				return ok, desc
+			}
+		}
+
+		for i := 1; i < len(ctxKeyAndValues); i += 2 {
+			if ok, desc := match.ContainsKeyValue(ctxKeyAndValues[i-1], ctxKeyAndValues[i]).Matches(ctxMap); !ok {
+				return ok, desc
+			}
+		}
+
+		return true, ""
+	}))
+}
+
+func (c *logCore) assert(name *string, level LogLevel, message any, ctxKeys []string, ctxMatcher match.Matcher) {
+	c.transition(logAsserting)
+
+	c.assertRecord(append(
+		logBaseChecks(name, &level, message),
+		logCtxCheck(ctxMatcher, ctxKeys, LogRecord.ctxMap),
+	))
+}
+
+func (c *logCore) assertRecord(checks []logFieldCheck) {
+	if c.orderless {
+		c.await(func() bool {
+			all := c.store.all()
+
+			for _, i := range c.nextIndexes() {
+				if ok, _ := logCheckFields(checks, all[i]); ok {
+					return true
+				}
+			}
+
+			return false
+		})
+
+		c.consumeSkipped()
+
+		var remaining []LogRecord
+
+		all := c.store.all()
+
+		for _, i := range c.nextIndexes() {
+			if ok, _ := logCheckFields(checks, all[i]); ok {
+				c.usedIndexes = append(c.usedIndexes, i)
+				logMatched(checks, all[i])
+
+				return
+			}
+
+			remaining = append(remaining, all[i])
+		}
+
+		if c.loose {
+			return
+		}
+
+		c.reportError(c.noMatchReport(checks, remaining))
+	}
+
+	c.await(func() bool {
+		return len(c.nextIndexes()) != 0
+	})
+
+	c.consumeSkipped()
+
+	indexes := c.nextIndexes()
+	if len(indexes) == 0 {
+		if c.loose {
+			return
+		}
+
+		c.reportError("There are no more logged entries" + c.ignoredNote())
+	}
+
+	record := c.store.all()[indexes[0]]
+	c.index = indexes[0] + 1
+
+	if ok, desc := logCheckFields(checks, record); !ok {
+		builder := &strings.Builder{}
+
+		builder.WriteString(strings.TrimPrefix(desc, "Fn(): "))
+		builder.WriteString("\n\n\tEntry: ")
+		formatLogRecord(builder, record)
+		builder.WriteString(mismatchesString(checks, record))
+
+		c.reportError(builder.String())
+	}
+
+	logMatched(checks, record)
+}
+
+// named returns the scope of the records logged by the named logger and its descendants, see Zap.Named().
+func (c *logCore) named(name string) *logCore {
+	if c.state == logCreated {
+		c.reportError("First call Get(), GetAt() or Use() methods")
+	}
+
+	for _, scope := range c.scopes {
+		if scope.scope == name {
+			return scope
+		}
+	}
+
+	// The scope starts at the parent's cursor, as the parent has already asserted the preceding records. Ignore()
+	// rules are looked up through the parent, so that the rules added to it later apply to the scope too.
+	scope := &logCore{
+		t:           c.t,
+		state:       logInitialized,
+		store:       c.store,
+		partial:     c.partial,
+		loose:       c.loose,
+		index:       c.index,
+		usedIndexes: append([]int(nil), c.usedIndexes...),
+		logPrefix:   c.logPrefix,
+		callerHint:  c.callerHint,
+		scope:       name,
+		parent:      c,
+	}
+
+	c.scopes = append(c.scopes, scope)
+
+	return scope
+}
+
+func (c *logCore) inScope(record LogRecord) bool {
+	if c.scope != "" && !logNameMatches(c.scope, record.LoggerName) {
+		return false
+	}
+
+	for _, scope := range c.scopes {
+		if logNameMatches(scope.scope, record.LoggerName) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *logCore) scopeString() string {
+	if c.parent == nil {
+		return ""
+	}
+
+	return c.parent.scopeString() + fmt.Sprintf("Named(%q): ", c.scope)
+}
+
+func logNameMatches(pattern, name string) bool {
+	if pattern == name || strings.HasPrefix(name, pattern+".") {
+		return true
+	}
+
+	matched, err := path.Match(pattern, name)
+
+	return err == nil && matched
+}
+
+func logCheckName(pattern, name string) (bool, string) {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return match.Equal(pattern).Matches(name)
+	}
+
+	if matched, err := path.Match(pattern, name); err == nil && matched {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("Name(%q): logger name not matched: %q", pattern, name)
+}
+
+func (c *logCore) getBool(b []bool, caller string) bool {
+	switch len(b) {
+	case 0:
+		return true
+	case 1:
+		return b[0]
+	default:
+		c.reportError(caller + "(): parameter must be a single bool, or nothing (equivalent to true)")
+
+		return false
+	}
+}
+
+func (c *logCore) reportError(err string) {
+	require.Fail(c.t, prefix+c.logPrefix+c.scopeString()+err)
+}
@@ -0,0 +1,90 @@
+package muchtest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grongor/go-muchtest/match"
+)
+
+// count returns the records with the given level that match the message and ctx, regardless of the AssertNext
+// cursor. Named scopes count only their own records; the records excluded by Ignore() are counted, as Ignore() only
+// lets the sequential assertions pass them.
+func (c *logCore) count(level LogLevel, message any, ctxKeyAndValues []any) int {
+	return len(c.findAll(level, false, message, ctxKeyAndValues))
+}
+
+func (c *logCore) assertCount(n int, level LogLevel, message any, ctxKeyAndValues []any) {
+	if records := c.findAll(level, false, message, ctxKeyAndValues); len(records) != n {
+		c.reportCount(fmt.Sprintf("AssertCount(%d, %s)", n, level.title()), records)
+	}
+}
+
+func (c *logCore) assertAtMost(n int, level LogLevel, message any, ctxKeyAndValues []any) {
+	if records := c.findAll(level, false, message, ctxKeyAndValues); len(records) > n {
+		c.reportCount(fmt.Sprintf("AssertAtMost(%d, %s)", n, level.title()), records)
+	}
+}
+
+// assertNone asserts that no records with the given level, or above, match the message and ctx.
+func (c *logCore) assertNone(level LogLevel, message any, ctxKeyAndValues []any) {
+	if records := c.findAll(level, true, message, ctxKeyAndValues); len(records) != 0 {
+		c.reportCount(fmt.Sprintf("AssertNone(%s)", level.title()), records)
+	}
+}
+
+func (c *logCore) findAll(level LogLevel, orAbove bool, message any, ctxKeyAndValues []any) []LogRecord {
+	if c.state == logCreated {
+		c.reportError("First call Get(), GetAt() or Use() methods")
+	}
+
+	if message == nil {
+		message = match.Any()
+	}
+
+	a := &logEntryAssert{c: c, message: message, partial: true}
+
+	var ctxMatcher match.Matcher
+	if len(ctxKeyAndValues) != 0 {
+		ctxMatcher = a.ctxMatcher(ctxKeyAndValues)
+	}
+
+	var records []LogRecord
+
+	for _, record := range c.store.all() {
+		if record.Level != level && (!orAbove || record.Level < level) {
+			continue
+		}
+
+		if c.scope != "" && !logNameMatches(c.scope, record.LoggerName) {
+			continue
+		}
+
+		if ok, _ := a.checkRecord(record, nil, nil, a.message, ctxMatcher); ok {
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
+func (c *logCore) reportCount(assertion string, records []LogRecord) {
+	builder := &strings.Builder{}
+
+	switch len(records) {
+	case 0:
+		builder.WriteString(assertion + ": no entries matched")
+	case 1:
+		builder.WriteString(assertion + ": 1 entry matched:\n")
+	default:
+		fmt.Fprintf(builder, "%s: %d entries matched:\n", assertion, len(records))
+	}
+
+	for _, record := range records {
+		builder.WriteByte('\t')
+		formatLogRecord(builder, record)
+		builder.WriteByte('\n')
+	}
+
+	c.reportError(builder.String())
+}
@@ -0,0 +1,263 @@
+package muchtest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grongor/go-muchtest/match"
+)
+
+type logFieldCheck struct {
+	field      string
+	check      func(record LogRecord) (bool, string)
+	similarity func(record LogRecord) float64
+	// matched is called with the record that passed all the checks.
+	matched func(record LogRecord)
+}
+
+func logBaseChecks(name *string, level *LogLevel, message any) []logFieldCheck {
+	var checks []logFieldCheck
+
+	if name != nil {
+		checks = append(checks, logFieldCheck{
+			field: "name",
+			check: func(record LogRecord) (bool, string) {
+				return logCheckName(*name, record.LoggerName)
+			},
+		})
+	}
+
+	if level != nil {
+		checks = append(checks, logFieldCheck{
+			field: "level",
+			check: func(record LogRecord) (bool, string) {
+				return match.Equal(*level).Matches(record.Level)
+			},
+		})
+	}
+
+	if message != nil {
+		// Records are checked while ranking and polling too, so Msgf() captures only from the record that matched.
+		matcher, capture := match.CaptureLater(match.ToMatcher(message))
+
+		check := logFieldCheck{
+			field: "message",
+			check: func(record LogRecord) (bool, string) {
+				return matcher.Matches(record.Message)
+			},
+			matched: func(record LogRecord) {
+				capture(record.Message)
+			},
+		}
+
+		if expected, ok := message.(string); ok {
+			check.similarity = func(record LogRecord) float64 {
+				return stringSimilarity(expected, record.Message)
+			}
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+func logCtxCheck(
+	ctxMatcher match.Matcher,
+	ctxKeys []string,
+	ctxMap func(record LogRecord) map[string]any,
+) logFieldCheck {
+	return logFieldCheck{
+		field: "ctx",
+		check: func(record LogRecord) (bool, string) {
+			return ctxMatcher.Matches(ctxMap(record))
+		},
+		similarity: func(record LogRecord) float64 {
+			return keysSimilarity(ctxKeys, ctxMap(record))
+		},
+	}
+}
+
+func logCtxKeys(ctxKeyAndValues []any) []string {
+	pairs, _ := logCtxPairs(ctxKeyAndValues)
+
+	keys := make([]string, 0, len(pairs))
+
+	for _, pair := range pairs {
+		if key, ok := pair.key.(string); ok {
+			keys = append(keys, strings.SplitN(key, ".", 2)[0])
+		}
+	}
+
+	return keys
+}
+
+func logCheckFields(checks []logFieldCheck, record LogRecord) (bool, string) {
+	for _, check := range checks {
+		if ok, desc := check.check(record); !ok {
+			if check.field == "ctx" {
+				desc += "\n"
+			}
+
+			return ok, desc
+		}
+	}
+
+	return true, ""
+}
+
+func logMatched(checks []logFieldCheck, record LogRecord) {
+	for _, check := range checks {
+		if check.matched != nil {
+			check.matched(record)
+		}
+	}
+}
+
+func logMismatches(checks []logFieldCheck, record LogRecord) []string {
+	var mismatches []string
+
+	for _, check := range checks {
+		if ok, desc := check.check(record); !ok {
+			desc = strings.ReplaceAll(strings.TrimSpace(strings.TrimPrefix(desc, "Fn(): ")), "\n", "\n\t\t\t")
+			mismatches = append(mismatches, check.field+": "+desc)
+		}
+	}
+
+	return mismatches
+}
+
+func mismatchesString(checks []logFieldCheck, record LogRecord) string {
+	mismatches := logMismatches(checks, record)
+	if len(mismatches) < 2 {
+		return ""
+	}
+
+	return "\n\tMismatches:\n\t\t" + strings.Join(mismatches, "\n\t\t") + "\n"
+}
+
+func (c *logCore) noMatchReport(checks []logFieldCheck, records []LogRecord) string {
+	builder := &strings.Builder{}
+	builder.WriteString("No entries matched and there are no more logged entries" + c.ignoredNote())
+
+	if len(records) == 0 {
+		return builder.String()
+	}
+
+	ranked := rankRecords(checks, records)
+
+	builder.WriteString(". Closest entry:\n\t")
+	formatLogRecord(builder, ranked[0])
+	builder.WriteString("\n\t\tMismatches:\n")
+
+	for _, mismatch := range logMismatches(checks, ranked[0]) {
+		builder.WriteString("\t\t\t")
+		builder.WriteString(mismatch)
+		builder.WriteByte('\n')
+	}
+
+	if len(ranked) > 1 {
+		fmt.Fprintf(builder, "\tOther entries (%d, most similar first):\n", len(ranked)-1)
+
+		for _, record := range ranked[1:] {
+			builder.WriteByte('\t')
+			formatLogRecord(builder, record)
+			builder.WriteByte('\n')
+		}
+	}
+
+	return builder.String()
+}
+
+func rankRecords(checks []logFieldCheck, records []LogRecord) []LogRecord {
+	scores := make([]float64, len(records))
+	indexes := make([]int, len(records))
+
+	for i, record := range records {
+		indexes[i] = i
+
+		for _, check := range checks {
+			if ok, _ := check.check(record); ok {
+				scores[i]++
+			} else if check.similarity != nil {
+				scores[i] += check.similarity(record)
+			}
+		}
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return scores[indexes[i]] > scores[indexes[j]]
+	})
+
+	ranked := make([]LogRecord, len(records))
+	for i, index := range indexes {
+		ranked[i] = records[index]
+	}
+
+	return ranked
+}
+
+func stringSimilarity(expected, actual string) float64 {
+	a, b := []rune(expected), []rune(actual)
+
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+
+	if longest == 0 {
+		return 1
+	}
+
+	// Levenshtein distance, keeping just the previous row of the matrix.
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return 1 - float64(previous[len(b)])/float64(longest)
+}
+
+func keysSimilarity(expected []string, actual map[string]any) float64 {
+	if len(expected) == 0 && len(actual) == 0 {
+		return 1
+	}
+
+	common := 0
+
+	for _, key := range expected {
+		if _, ok := actual[key]; ok {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(expected)+len(actual)-common)
+}
+
+func minInt(first int, others ...int) int {
+	for _, other := range others {
+		if other < first {
+			first = other
+		}
+	}
+
+	return first
+}
@@ -0,0 +1,150 @@
+package muchtest_test
+
+import (
+	"log/slog"
+	"regexp"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/internal"
+	"github.com/grongor/go-muchtest/match"
+	"github.com/grongor/go-muchtest/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	muchtest.RegisterLogAdapter("test", func(recorder muchtest.LogRecorder) any {
+		return &testLogger{recorder: recorder}
+	})
+}
+
+type testLogger struct {
+	recorder muchtest.LogRecorder
+}
+
+func (l *testLogger) With(key string, value any) *testLogger {
+	return &testLogger{recorder: l.recorder.With([]muchtest.LogAttr{{Key: key, Value: value}})}
+}
+
+func (l *testLogger) Log(message string, attrs ...muchtest.LogAttr) {
+	if !l.recorder.Enabled(muchtest.LogInfo) {
+		return
+	}
+
+	_ = l.recorder.Record(muchtest.LogRecord{
+		Time:       time.Unix(1, 0),
+		Level:      muchtest.LogInfo,
+		LoggerName: "test",
+		Message:    message,
+		CallerFile: "/much/test.go",
+		CallerLine: 42,
+		Attrs:      attrs,
+	})
+}
+
+func TestLogsSuite(t *testing.T) {
+	muchtest.Run(t, new(LogsSuite))
+}
+
+type LogsSuite struct {
+	muchtest.Suite
+
+	TestingT *mocks.TestingT
+
+	z *muchtest.Zap
+}
+
+func (s *LogsSuite) BeforeTest(suiteName, testName string) {
+	s.z = muchtest.NewZap(s.TestingT)
+
+	s.TestingT.EXPECT().Helper().Maybe()
+}
+
+func (s *LogsSuite) TestRecorder_Levels() {
+	recorder := s.z.Recorder()
+
+	s.S.False(recorder.Enabled(muchtest.LogDebug))
+	s.S.True(recorder.Enabled(muchtest.LogInfo))
+	s.S.NoError(recorder.Record(muchtest.LogRecord{Level: muchtest.LogWarn, Message: "much"}))
+	s.S.NoError(recorder.Record(muchtest.LogRecord{Level: muchtest.LogError, Message: "wow"}))
+	s.S.NoError(recorder.Record(muchtest.LogRecord{Level: muchtest.LogPanic, Message: "such"}))
+	s.S.NoError(recorder.Record(muchtest.LogRecord{Level: muchtest.LogFatal, Message: "test"}))
+
+	s.z.Warn("much").NoCtx()
+	s.z.Error("wow").NoCtx()
+	s.z.Panic("such").NoCtx()
+	s.z.Fatal("test").NoCtx()
+}
+
+func (s *LogsSuite) TestAdapt() {
+	logger := s.z.Adapt("test").(*testLogger)
+
+	logger.With("requestID", "abc").Log("much", muchtest.LogAttr{Key: "wow", Value: 1},
+		muchtest.LogAttr{Key: "user", Value: []muchtest.LogAttr{{Key: "id", Value: 42}}},
+		muchtest.LogAttr{Key: "", Value: []muchtest.LogAttr{{Key: "inline", Value: true}}},
+		muchtest.LogAttr{Key: "empty", Value: []muchtest.LogAttr{}},
+	)
+
+	s.z.Assert("test").Info("much").
+		Caller("/much/test.go").
+		Time(match.SameInstant(time.Unix(1, 0))).
+		With("requestID", "abc").
+		Ctx("wow", 1, "user.id", 42, "inline", true)
+}
+
+func (s *LogsSuite) TestAdapt_Slog() {
+	logger := s.z.Adapt("slog").(*slog.Logger)
+	logger.Debug("much", "wow", 1)
+
+	s.z.AssertNoNextEntry()
+
+	logger.WithGroup("request").Info("such", "id", 42)
+	s.z.Info("such").Ctx("request.id", 42)
+}
+
+func (s *LogsSuite) TestAdapt_Unknown() {
+	s.expectErr(`Adapt("nope"): no such adapter, registered: slog, test`, func() {
+		s.z.Adapt("nope")
+	})
+}
+
+func (s *LogsSuite) TestRecorder_Use() {
+	s.z.Use(nil, nil)
+
+	s.expectErr(`Recorder() is only available with Get() or GetAt(), not with Use()`, func() {
+		s.z.Recorder()
+	})
+}
+
+func (s *LogsSuite) TestRegisterLogAdapter_Duplicate() {
+	s.S.PanicsWithValue(`muchtest.RegisterLogAdapter(): adapter "slog" is already registered`, func() {
+		muchtest.RegisterLogAdapter("slog", nil)
+	})
+}
+
+func (s *LogsSuite) expectErr(desc string, fn func()) {
+	s.T().Helper()
+
+	s.TestingT.EXPECT().Errorf("\n%s", mock.MatchedBy(func(actual string) bool {
+		return regexp.MustCompile(`(?s)\s*Error Trace:(.*?)Error:\s+muchtest: zap: ` + regexp.QuoteMeta(desc)).
+			MatchString(internal.TrimDiff(actual))
+	})).Once()
+
+	s.TestingT.EXPECT().FailNow().Once().Run(func(mock.Arguments) { runtime.Goexit() })
+
+	done := make(chan bool)
+
+	go func() {
+		defer close(done)
+
+		fn()
+
+		done <- true
+	}()
+
+	if notExited := <-done; notExited {
+		s.Fail("expected goroutine to exit")
+	}
+}
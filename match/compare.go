@@ -0,0 +1,186 @@
+package match
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+func Greater(than any) Matcher {
+	return newCompareMatcher("Greater", than, "not greater", func(c int) bool { return c > 0 })
+}
+
+func GreaterOrEqual(than any) Matcher {
+	return newCompareMatcher("GreaterOrEqual", than, "not greater or equal", func(c int) bool { return c >= 0 })
+}
+
+func Less(than any) Matcher {
+	return newCompareMatcher("Less", than, "not less", func(c int) bool { return c < 0 })
+}
+
+func LessOrEqual(than any) Matcher {
+	return newCompareMatcher("LessOrEqual", than, "not less or equal", func(c int) bool { return c <= 0 })
+}
+
+func Positive() Matcher {
+	m := newCompareMatcher("Positive", 0, "not positive", func(c int) bool { return c > 0 })
+	m.noArgs = true
+
+	return m
+}
+
+func Negative() Matcher {
+	m := newCompareMatcher("Negative", 0, "not negative", func(c int) bool { return c < 0 })
+	m.noArgs = true
+
+	return m
+}
+
+func Increasing() Matcher {
+	return orderedMatcher{name: "Increasing", desc: "not increasing", fn: func(c int) bool { return c < 0 }}
+}
+
+func NonIncreasing() Matcher {
+	return orderedMatcher{name: "NonIncreasing", desc: "increasing", fn: func(c int) bool { return c >= 0 }}
+}
+
+func Decreasing() Matcher {
+	return orderedMatcher{name: "Decreasing", desc: "not decreasing", fn: func(c int) bool { return c > 0 }}
+}
+
+func NonDecreasing() Matcher {
+	return orderedMatcher{name: "NonDecreasing", desc: "decreasing", fn: func(c int) bool { return c <= 0 }}
+}
+
+func newCompareMatcher(name string, than any, desc string, fn func(int) bool) compareMatcher {
+	return compareMatcher{name: name, than: than, desc: desc, fn: fn}
+}
+
+type compareMatcher struct {
+	name   string
+	than   any
+	desc   string
+	fn     func(int) bool
+	noArgs bool
+}
+
+func (m compareMatcher) Matches(actual any) (ok bool, desc string) {
+	c, ok := compare(reflectV(actual), reflectV(m.than))
+	if !ok {
+		return false, fmt.Sprintf("%s: not comparable: %T(%s)", m.String(), actual, formatValue(actual))
+	}
+
+	if m.fn(c) {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("%s: %s: %s", m.String(), m.desc, formatValue(actual))
+}
+
+func (m compareMatcher) String() string {
+	if m.noArgs {
+		return m.name + "()"
+	}
+
+	return fmt.Sprintf("%s(%s)", m.name, formatValue(m.than))
+}
+
+type orderedMatcher struct {
+	name string
+	desc string
+	fn   func(int) bool
+}
+
+func (m orderedMatcher) Matches(actual any) (ok bool, desc string) {
+	vActual := indirect(reflectV(actual))
+
+	switch vActual.Kind() {
+	case reflect.Slice, reflect.Array:
+	default:
+		return false, fmt.Sprintf("%s: not a slice or array: %T(%s)", m.String(), actual, formatValue(actual))
+	}
+
+	for i := 1; i < vActual.Len(); i++ {
+		c, ok := compare(vActual.Index(i-1), vActual.Index(i))
+		if !ok {
+			return false, fmt.Sprintf("%s: not comparable at index %d: %s", m.String(), i, formatValue(actual))
+		}
+
+		if !m.fn(c) {
+			return false, fmt.Sprintf("%s: %s at index %d: %s", m.String(), m.desc, i, formatValue(actual))
+		}
+	}
+
+	return true, ""
+}
+
+func (m orderedMatcher) String() string {
+	return m.name + "()"
+}
+
+func compare(a, b reflect.Value) (int, bool) {
+	a = indirect(a)
+	b = indirect(b)
+
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), true
+	}
+
+	if a.CanInterface() && b.CanInterface() {
+		if tA, ok := a.Interface().(time.Time); ok {
+			tB, ok := b.Interface().(time.Time)
+			if !ok {
+				return 0, false
+			}
+
+			switch {
+			case tA.Before(tB):
+				return -1, true
+			case tA.After(tB):
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+	}
+
+	var values [2]*big.Float
+	var withFloat32 bool
+
+	for i, v := range [...]reflect.Value{a, b} {
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			values[i] = new(big.Float).SetInt64(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			values[i] = new(big.Float).SetUint64(v.Uint())
+		case reflect.Float32:
+			withFloat32 = true
+
+			fallthrough
+		case reflect.Float64:
+			if math.IsNaN(v.Float()) {
+				return 0, false
+			}
+
+			values[i] = big.NewFloat(v.Float())
+		default:
+			return 0, false
+		}
+	}
+
+	if withFloat32 {
+		for i, v := range values {
+			f32, _ := v.Float32()
+			values[i] = big.NewFloat(float64(f32))
+		}
+	}
+
+	return values[0].Cmp(values[1]), true
+}
@@ -0,0 +1,80 @@
+package match_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestCompareSuite(t *testing.T) {
+	muchtest.Run(t, new(CompareSuite))
+}
+
+type CompareSuite struct {
+	pkgSuite
+}
+
+func (s *CompareSuite) TestGreaterAndLess() {
+	s.S.Equal("Greater(5)", match.Greater(5).String())
+	s.S.Equal("LessOrEqual(\"b\")", match.LessOrEqual("b").String())
+
+	s.match(match.Greater(5), 6)
+	s.match(match.Greater(5), uint8(6))
+	s.match(match.Greater(5), 5.1)
+	s.match(match.Greater("a"), "b")
+	s.match(match.Greater(5), 5, "Greater(5): not greater: 5")
+	s.match(match.Greater(5), float32(4.9), "Greater(5): not greater: 4.9")
+	s.match(match.Greater(5), "6", `Greater(5): not comparable: string("6")`)
+
+	s.match(match.GreaterOrEqual(5), 5)
+	s.match(match.GreaterOrEqual(5), int64(4), "GreaterOrEqual(5): not greater or equal: 4")
+
+	s.match(match.Less(5), -1)
+	s.match(match.Less(uint(5)), 4.99)
+	s.match(match.Less("b"), "b", `Less("b"): not less: "b"`)
+
+	s.match(match.LessOrEqual(5), 5)
+	s.match(match.LessOrEqual(5), 6, "LessOrEqual(5): not less or equal: 6")
+
+	now := time.Date(2022, 8, 26, 13, 14, 58, 0, time.UTC)
+
+	s.match(match.Greater(now), now.Add(time.Second))
+	s.match(match.Greater(now), now, "Greater(2022-08-26 13:14:58): not greater: 2022-08-26 13:14:58")
+	s.match(match.Greater(now), 5, "Greater(2022-08-26 13:14:58): not comparable: int(5)")
+}
+
+func (s *CompareSuite) TestPositiveAndNegative() {
+	s.S.Equal("Positive()", match.Positive().String())
+	s.S.Equal("Negative()", match.Negative().String())
+
+	s.match(match.Positive(), 1)
+	s.match(match.Positive(), 0.1)
+	s.match(match.Positive(), 0, "Positive(): not positive: 0")
+	s.match(match.Positive(), int8(-1), "Positive(): not positive: -1")
+
+	s.match(match.Negative(), -1)
+	s.match(match.Negative(), -0.1)
+	s.match(match.Negative(), uint(0), "Negative(): not negative: 0")
+	s.match(match.Negative(), "-1", `Negative(): not comparable: string("-1")`)
+}
+
+func (s *CompareSuite) TestOrdered() {
+	s.S.Equal("Increasing()", match.Increasing().String())
+
+	s.match(match.Increasing(), []int{1, 2, 3})
+	s.match(match.Increasing(), []int{})
+	s.match(match.Increasing(), [2]string{"a", "b"})
+	s.match(match.Increasing(), []int{1, 1, 3}, "Increasing(): not increasing at index 1: []int{1, 1, 3}")
+
+	s.match(match.NonIncreasing(), []float64{2, 1, 1})
+	s.match(match.NonIncreasing(), []float64{2, 3}, "NonIncreasing(): increasing at index 1: []float64{2, 3}")
+
+	s.match(match.Decreasing(), []uint{3, 2, 1})
+	s.match(match.Decreasing(), []uint{3, 3}, "Decreasing(): not decreasing at index 1: []uint{3, 3}")
+
+	s.match(match.NonDecreasing(), []any{1, 1.5, uint8(2)})
+	s.match(match.NonDecreasing(), []any{1, "2"}, `NonDecreasing(): not comparable at index 1: []any{1, "2"}`)
+	s.match(match.NonDecreasing(), 123, "NonDecreasing(): not a slice or array: int(123)")
+}
@@ -44,9 +44,11 @@ type containsMatcher struct {
 
 func (m containsMatcher) Matches(actual any) (ok bool, desc string) {
 	vContainer := reflectV(actual)
-	tContainer := vContainer.Type()
+	if !vContainer.IsValid() {
+		return false, m.String() + ": not a container: nil"
+	}
 
-	switch kind := tContainer.Kind(); kind {
+	switch kind := vContainer.Kind(); kind {
 	case reflect.String:
 		return m.stringContains(vContainer)
 	case reflect.Slice:
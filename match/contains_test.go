@@ -147,4 +147,8 @@ func (s *ContainsSuite) TestContains() {
 	s.match(match.Contains(""), actual, `Contains(""): unsupported container kind: float64`)
 	s.match(match.ContainsKey(""), actual, `ContainsKey(""): unsupported container kind: float64`)
 	s.match(match.ContainsKeyValue("", ""), actual, `ContainsKeyValue("", ""): unsupported container kind: float64`)
+
+	s.match(match.Contains(1), nil, `Contains(1): not a container: nil`)
+	s.match(match.ContainsKey(1), (*[]int)(nil), `ContainsKey(1): not a container: nil`)
+	s.match(match.Prefix("M"), (*string)(nil), `Prefix("M"): not a container: nil`)
 }
@@ -0,0 +1,126 @@
+package match
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+func InDelta(expected any, delta float64) Matcher {
+	return deltaMatcher{expected: expected, delta: delta}
+}
+
+func InEpsilon(expected any, epsilon float64) Matcher {
+	return deltaMatcher{expected: expected, delta: epsilon, relative: true}
+}
+
+type deltaMatcher struct {
+	expected any
+	delta    float64
+	relative bool
+}
+
+func (m deltaMatcher) Matches(actual any) (ok bool, desc string) {
+	if desc = m.doMatches(indirect(reflectV(m.expected)), indirect(reflectV(actual))); desc != "" {
+		return false, fmt.Sprintf("%s: %s: %s", m.String(), desc, formatValue(actual))
+	}
+
+	return true, ""
+}
+
+func (m deltaMatcher) doMatches(vExpected, vActual reflect.Value) string {
+	switch vExpected.Kind() {
+	case reflect.Slice, reflect.Array:
+		if vActual.Kind() != reflect.Slice && vActual.Kind() != reflect.Array {
+			return "not a slice or array"
+		}
+
+		if vExpected.Len() != vActual.Len() {
+			return fmt.Sprintf("different length %d", vActual.Len())
+		}
+
+		for i := 0; i < vExpected.Len(); i++ {
+			if desc := m.doMatches(indirect(vExpected.Index(i)), indirect(vActual.Index(i))); desc != "" {
+				return fmt.Sprintf("at index %d: %s", i, desc)
+			}
+		}
+
+		return ""
+	case reflect.Map:
+		if vActual.Kind() != reflect.Map {
+			return "not a map"
+		}
+
+		if vExpected.Len() != vActual.Len() {
+			return fmt.Sprintf("different length %d", vActual.Len())
+		}
+
+		for _, vKey := range vExpected.MapKeys() {
+			vActualValue := vActual.MapIndex(vKey)
+			if !vActualValue.IsValid() {
+				return fmt.Sprintf("missing key %s", formatValue(vKey))
+			}
+
+			if desc := m.doMatches(indirect(vExpected.MapIndex(vKey)), indirect(vActualValue)); desc != "" {
+				return fmt.Sprintf("at key %s: %s", formatValue(vKey), desc)
+			}
+		}
+
+		return ""
+	}
+
+	expected, ok := toFloat(vExpected)
+	if !ok {
+		return "expected value is not a number"
+	}
+
+	value, ok := toFloat(vActual)
+	if !ok {
+		return "not a number"
+	}
+
+	if math.IsNaN(expected) || math.IsNaN(value) {
+		return "NaN can't be compared"
+	}
+
+	diff := math.Abs(expected - value)
+
+	if m.relative {
+		if expected == 0 {
+			return "relative error can't be calculated for expected value 0"
+		}
+
+		if diff /= math.Abs(expected); diff > m.delta {
+			return fmt.Sprintf("relative error %v exceeds epsilon", diff)
+		}
+
+		return ""
+	}
+
+	if diff > m.delta {
+		return fmt.Sprintf("difference %v exceeds delta", diff)
+	}
+
+	return ""
+}
+
+func (m deltaMatcher) String() string {
+	if m.relative {
+		return fmt.Sprintf("InEpsilon(%s, %v)", formatValue(m.expected), m.delta)
+	}
+
+	return fmt.Sprintf("InDelta(%s, %v)", formatValue(m.expected), m.delta)
+}
+
+func toFloat(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,50 @@
+package match_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestDeltaSuite(t *testing.T) {
+	muchtest.Run(t, new(DeltaSuite))
+}
+
+type DeltaSuite struct {
+	pkgSuite
+}
+
+func (s *DeltaSuite) TestInDelta() {
+	s.S.Equal("InDelta(3.14, 0.01)", match.InDelta(3.14, 0.01).String())
+
+	s.match(match.InDelta(math.Pi, 0.01), 22/7.0)
+	s.match(match.InDelta(10, 1), uint8(9))
+	s.match(match.InDelta(10, 1), 11.5, "InDelta(10, 1): difference 1.5 exceeds delta: 11.5")
+	s.match(match.InDelta(10, 1), "10", `InDelta(10, 1): not a number: "10"`)
+	s.match(match.InDelta("10", 1), 10, `InDelta("10", 1): expected value is not a number: 10`)
+	s.match(match.InDelta(10, 1), math.NaN(), "InDelta(10, 1): NaN can't be compared: NaN")
+
+	s.match(match.InDelta([]float64{1, 2}, 0.1), []float32{1.05, 1.95})
+	s.match(match.InDelta([]float64{1, 2}, 0.1), []float64{1, 2.5},
+		"InDelta([]float64{1, 2}, 0.1): at index 1: difference 0.5 exceeds delta: []float64{1, 2.5}")
+	s.match(match.InDelta([]float64{1, 2}, 0.1), []float64{1},
+		"InDelta([]float64{1, 2}, 0.1): different length 1: []float64{1}")
+	s.match(match.InDelta([]float64{1, 2}, 0.1), 1.0, "InDelta([]float64{1, 2}, 0.1): not a slice or array: 1")
+
+	s.match(match.InDelta(map[string]float64{"a": 1}, 0.1), map[string]float64{"a": 1.05})
+	s.match(match.InDelta(map[string]float64{"a": 1}, 0.1), map[string]float64{"b": 1},
+		`InDelta(map[string]float64{"a":1}, 0.1): missing key "a": map[string]float64{"b":1}`)
+	s.match(match.InDelta(map[string]float64{"a": 1}, 0.1), map[string]float64{"a": 2},
+		`InDelta(map[string]float64{"a":1}, 0.1): at key "a": difference 1 exceeds delta: map[string]float64{"a":2}`)
+}
+
+func (s *DeltaSuite) TestInEpsilon() {
+	s.S.Equal("InEpsilon(100, 0.1)", match.InEpsilon(100, 0.1).String())
+
+	s.match(match.InEpsilon(100, 0.1), 109)
+	s.match(match.InEpsilon(100, 0.1), 111, "InEpsilon(100, 0.1): relative error 0.11 exceeds epsilon: 111")
+	s.match(match.InEpsilon(0, 0.1), 0, "InEpsilon(0, 0.1): relative error can't be calculated for expected value 0: 0")
+	s.match(match.InEpsilon([]int{100, -100}, 0.1), []int{95, -95})
+}
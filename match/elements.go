@@ -0,0 +1,373 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Each and the other collection matchers accept slices, arrays, maps (their values) and buffered channels. Channels
+// are received from without blocking, and the values are sent back to them afterwards; closed channels can't take
+// them back, so matching consumes their values. Unbuffered channels are rejected, as their values would be lost.
+func Each(expected any) Matcher {
+	return eachMatcher{matcher: ToMatcher(expected)}
+}
+
+func ElementsMatch(expected ...any) Matcher {
+	return newElementsMatcher("ElementsMatch", expected)
+}
+
+func ContainsAll(expected ...any) Matcher {
+	m := newElementsMatcher("ContainsAll", expected)
+	m.partial = true
+
+	return m
+}
+
+func Sequence(expected ...any) Matcher {
+	return sequenceMatcher{expected: expected, matchers: ToMatchers(expected)}
+}
+
+func Sorted(lessFn any) Matcher {
+	if lessFn == nil {
+		return sortedMatcher{}
+	}
+
+	vLess := reflectV(lessFn)
+	tLess := vLess.Type()
+
+	if tLess.Kind() != reflect.Func || vLess.IsNil() || tLess.NumIn() != 2 || tLess.In(0) != tLess.In(1) ||
+		tLess.NumOut() != 1 || tLess.Out(0).Kind() != reflect.Bool {
+		return matcherErr("Invalid Sorted(): lessFn must be nil or a func(a, b T) bool.", lessFn)
+	}
+
+	return sortedMatcher{less: vLess}
+}
+
+type element struct {
+	value any
+	label string
+}
+
+// collectElements returns the elements of the collection, or the description of why they can't be collected.
+func collectElements(m Matcher, name string, actual any) ([]element, string) {
+	vActual := indirect(reflectV(actual))
+	for vActual.Kind() == reflect.Pointer && !vActual.IsNil() {
+		vActual = vActual.Elem()
+	}
+
+	var elements []element
+
+	switch vActual.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < vActual.Len(); i++ {
+			elements = append(elements, element{value: elementValue(vActual.Index(i)), label: fmt.Sprintf("index %d", i)})
+		}
+	case reflect.Map:
+		for _, vKey := range sortedMapKeys(vActual) {
+			elements = append(elements, element{
+				value: elementValue(vActual.MapIndex(vKey)),
+				label: "key " + formatReflectValue(vKey),
+			})
+		}
+	case reflect.Chan:
+		if vActual.Type().ChanDir()&reflect.RecvDir == 0 {
+			return nil, notCollection(m, actual)
+		}
+
+		if vActual.Cap() == 0 {
+			return nil, fmt.Sprintf("Invalid %s(): unbuffered channel", name)
+		}
+
+		var received []reflect.Value
+
+		closed := false
+
+		for {
+			vElement, ok := vActual.TryRecv()
+			if !ok {
+				closed = vElement.IsValid()
+
+				break
+			}
+
+			received = append(received, vElement)
+			elements = append(elements, element{
+				value: elementValue(vElement),
+				label: fmt.Sprintf("index %d", len(received)-1),
+			})
+		}
+
+		// Put the values back, so that Not(), AnyOf(), retries etc. see the same data. That's impossible with closed
+		// channels.
+		if !closed {
+			for _, vElement := range received {
+				if !vActual.TrySend(vElement) {
+					break
+				}
+			}
+		}
+	default:
+		return nil, notCollection(m, actual)
+	}
+
+	return elements, ""
+}
+
+func elementValue(vElement reflect.Value) any {
+	if vElement.CanInterface() {
+		return vElement.Interface()
+	}
+
+	return vElement
+}
+
+func elementsValue(elements []element) string {
+	values := make([]any, len(elements))
+	for i, e := range elements {
+		values[i] = e.value
+	}
+
+	return formatValue(values)
+}
+
+func notCollection(m Matcher, actual any) string {
+	return fmt.Sprintf("%s: not a slice, array, map or channel: %T(%s)", m.String(), actual, formatValue(actual))
+}
+
+type eachMatcher struct {
+	matcher Matcher
+}
+
+func (m eachMatcher) Matches(actual any) (ok bool, desc string) {
+	elements, err := collectElements(m, "Each", actual)
+	if err != "" {
+		return false, err
+	}
+
+	diff := &equalDiff{}
+
+	for _, e := range elements {
+		if ok, desc := m.matcher.Matches(e.value); !ok {
+			diff.add(e.label, "%s", desc)
+		}
+	}
+
+	if len(diff.paths) == 0 {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("%s: not matched: %s%s", m.String(), elementsValue(elements), diff.String())
+}
+
+func (m eachMatcher) String() string {
+	return fmt.Sprintf("Each(%s)", m.matcher.String())
+}
+
+func newElementsMatcher(name string, expected []any) elementsMatcher {
+	return elementsMatcher{name: name, expected: expected, matchers: ToMatchers(expected)}
+}
+
+type elementsMatcher struct {
+	name     string
+	expected []any
+	matchers []Matcher
+	partial  bool
+}
+
+func (m elementsMatcher) Matches(actual any) (ok bool, desc string) {
+	elements, err := collectElements(m, m.name, actual)
+	if err != "" {
+		return false, err
+	}
+
+	matches := make([][]bool, len(m.matchers))
+
+	for i, matcher := range m.matchers {
+		matches[i] = make([]bool, len(elements))
+
+		for j, e := range elements {
+			matches[i][j], _ = matcher.Matches(e.value)
+		}
+	}
+
+	matcherToElement := maxBipartiteMatching(matches, len(elements))
+	matchedElements := make([]bool, len(elements))
+	diff := &equalDiff{}
+
+	for i, j := range matcherToElement {
+		if j == -1 {
+			diff.add(fmt.Sprintf("expected[%d]", i), "no element matched: %s", formatValue(m.expected[i]))
+		} else {
+			matchedElements[j] = true
+		}
+	}
+
+	if !m.partial {
+		for j, e := range elements {
+			if !matchedElements[j] {
+				diff.add(e.label, "unexpected element: %s", formatValue(e.value))
+			}
+		}
+	}
+
+	if len(diff.paths) == 0 {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("%s: not matched: %s%s", m.String(), elementsValue(elements), diff.String())
+}
+
+func (m elementsMatcher) String() string {
+	args := strings.TrimSuffix(strings.TrimPrefix(formatValue(m.expected), "[]any{"), "}")
+
+	return fmt.Sprintf("%s(%s)", m.name, args)
+}
+
+func maxBipartiteMatching(matches [][]bool, elementsCount int) []int {
+	elementToMatcher := make([]int, elementsCount)
+	for j := range elementToMatcher {
+		elementToMatcher[j] = -1
+	}
+
+	var augment func(i int, visited []bool) bool
+	augment = func(i int, visited []bool) bool {
+		for j, ok := range matches[i] {
+			if !ok || visited[j] {
+				continue
+			}
+
+			visited[j] = true
+
+			if elementToMatcher[j] == -1 || augment(elementToMatcher[j], visited) {
+				elementToMatcher[j] = i
+
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for i := range matches {
+		augment(i, make([]bool, elementsCount))
+	}
+
+	matcherToElement := make([]int, len(matches))
+	for i := range matcherToElement {
+		matcherToElement[i] = -1
+	}
+
+	for j, i := range elementToMatcher {
+		if i != -1 {
+			matcherToElement[i] = j
+		}
+	}
+
+	return matcherToElement
+}
+
+type sequenceMatcher struct {
+	expected []any
+	matchers []Matcher
+}
+
+func (m sequenceMatcher) Matches(actual any) (ok bool, desc string) {
+	elements, err := collectElements(m, "Sequence", actual)
+	if err != "" {
+		return false, err
+	}
+
+	next := 0
+
+	for i, matcher := range m.matchers {
+		for ; next < len(elements); next++ {
+			if ok, _ := matcher.Matches(elements[next].value); ok {
+				break
+			}
+		}
+
+		if next == len(elements) {
+			after := ""
+			if i != 0 {
+				after = " after previous match"
+			}
+
+			return false, fmt.Sprintf("%s: not matched: %s%s", m.String(), elementsValue(elements), (&equalDiff{
+				paths: []string{fmt.Sprintf("expected[%d]", i)},
+				descs: []string{fmt.Sprintf("no element matched%s: %s", after, formatValue(m.expected[i]))},
+			}).String())
+		}
+
+		next++
+	}
+
+	return true, ""
+}
+
+func (m sequenceMatcher) String() string {
+	args := strings.TrimSuffix(strings.TrimPrefix(formatValue(m.expected), "[]any{"), "}")
+
+	return fmt.Sprintf("Sequence(%s)", args)
+}
+
+type sortedMatcher struct {
+	less reflect.Value
+}
+
+func (m sortedMatcher) Matches(actual any) (ok bool, desc string) {
+	elements, err := collectElements(m, "Sorted", actual)
+	if err != "" {
+		return false, err
+	}
+
+	for i := 1; i < len(elements); i++ {
+		prev, cur := elements[i-1], elements[i]
+
+		less, err := m.isLess(cur.value, prev.value)
+		if err != "" {
+			return false, fmt.Sprintf("%s: %s: %s", m.String(), err, elementsValue(elements))
+		}
+
+		if less {
+			return false, fmt.Sprintf("%s: not sorted at %s: %s before %s: %s", m.String(), cur.label,
+				formatValue(prev.value), formatValue(cur.value), elementsValue(elements))
+		}
+	}
+
+	return true, ""
+}
+
+func (m sortedMatcher) isLess(a, b any) (bool, string) {
+	vA, vB := reflectV(a), reflectV(b)
+
+	if !m.less.IsValid() {
+		result, ok := compare(vA, vB)
+		if !ok {
+			return false, fmt.Sprintf("not comparable: %s, %s", formatValue(a), formatValue(b))
+		}
+
+		return result < 0, ""
+	}
+
+	tArg := m.less.Type().In(0)
+	args := []reflect.Value{vA, vB}
+
+	for i, arg := range args {
+		if !arg.IsValid() {
+			args[i] = reflect.Zero(tArg)
+		} else if !arg.Type().AssignableTo(tArg) {
+			return false, fmt.Sprintf("element not assignable to %s: %s", tArg, formatReflectValue(arg))
+		}
+	}
+
+	return m.less.Call(args)[0].Bool(), ""
+}
+
+func (m sortedMatcher) String() string {
+	if !m.less.IsValid() {
+		return "Sorted()"
+	}
+
+	return fmt.Sprintf("Sorted(%s)", m.less.Type())
+}
@@ -0,0 +1,139 @@
+package match_test
+
+import (
+	"testing"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/internal"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestElementsSuite(t *testing.T) {
+	muchtest.Run(t, new(ElementsSuite))
+}
+
+type ElementsSuite struct {
+	pkgSuite
+}
+
+func (s *ElementsSuite) matchDiff(matcher match.Matcher, actual any, expectedDesc string) {
+	s.T().Helper()
+
+	ok, desc := matcher.Matches(actual)
+	s.S.False(ok)
+	s.S.Equal(expectedDesc, desc)
+}
+
+func (s *ElementsSuite) TestEach() {
+	s.S.Equal("Each(Positive())", match.Each(match.Positive()).String())
+	s.S.Equal("Each(Equal(1))", match.Each(1).String())
+
+	s.match(match.Each(match.Positive()), []int{})
+	s.match(match.Each(match.Positive()), []int{1, 2, 3})
+	s.match(match.Each(match.Prefix("M")), [2]string{"Much", "Muchness"})
+	s.match(match.Each(match.Positive()), map[string]int{"a": 1, "b": 2})
+	s.match(match.Each(1), &[]int{1, 1})
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	s.match(match.Each(match.Positive()), ch)
+
+	s.matchDiff(match.Each(match.Positive()), []int{1, -2, 3, 0},
+		"Each(Positive()): not matched: []any{1, -2, 3, 0}"+internal.DiffPrefix+
+			"\t\tindex 1: Positive(): not positive: -2\n"+
+			"\t\tindex 3: Positive(): not positive: 0\n")
+	s.matchDiff(match.Each(match.Positive()), map[string]int{"b": -2, "a": 1},
+		`Each(Positive()): not matched: []any{1, -2}`+internal.DiffPrefix+
+			"\t\tkey \"b\": Positive(): not positive: -2\n")
+	s.match(match.Each(1), 1, "Each(Equal(1)): not a slice, array, map or channel: int(1)")
+	s.match(match.Each(1), nil, "Each(Equal(1)): not a slice, array, map or channel: <nil>(nil)")
+}
+
+func (s *ElementsSuite) TestElementsMatch() {
+	s.S.Equal(`ElementsMatch(1, "Much")`, match.ElementsMatch(1, "Much").String())
+
+	s.match(match.ElementsMatch(), []int{})
+	s.match(match.ElementsMatch(1, 3, 2, 3), []int{1, 3, 3, 2})
+	s.match(match.ElementsMatch(1, match.Any()), [2]any{"Much", uint8(1)})
+	s.match(match.ElementsMatch(match.Any(), 1), [2]any{uint8(1), "Much"})
+	s.match(match.ElementsMatch(match.Positive(), 1), []int{1, 5})
+	s.match(match.ElementsMatch(match.Between(1, 5), match.Between(1, 2), 1), []int{2, 1, 4})
+	s.match(match.ElementsMatch("a", "b"), map[int]string{1: "b", 2: "a"})
+
+	s.matchDiff(match.ElementsMatch(1, 2), []int{1},
+		"ElementsMatch(1, 2): not matched: []any{1}"+internal.DiffPrefix+
+			"\t\texpected[1]: no element matched: 2\n")
+	s.matchDiff(match.ElementsMatch(1, 1, 2), []int{1, 2, 2},
+		"ElementsMatch(1, 1, 2): not matched: []any{1, 2, 2}"+internal.DiffPrefix+
+			"\t\texpected[1]: no element matched: 1\n"+
+			"\t\tindex 2: unexpected element: 2\n")
+	s.match(match.ElementsMatch(1), 1, "ElementsMatch(1): not a slice, array, map or channel: int(1)")
+
+	unbuffered := make(chan int)
+	go func() { unbuffered <- 1 }()
+
+	s.match(match.ElementsMatch(1), unbuffered, "Invalid ElementsMatch(): unbuffered channel")
+	s.S.Equal(1, <-unbuffered)
+}
+
+func (s *ElementsSuite) TestContainsAll() {
+	s.S.Equal(`ContainsAll(1, Positive())`, match.ContainsAll(1, match.Positive()).String())
+
+	s.match(match.ContainsAll(), []int{1})
+	s.match(match.ContainsAll(3, 1), []int{1, 2, 3})
+	s.match(match.ContainsAll(match.Positive(), 1), []int{0, 1, 5})
+	s.match(match.ContainsAll("a"), map[int]string{1: "b", 2: "a"})
+
+	s.matchDiff(match.ContainsAll(1, 1, 4), []int{1, 2, 3},
+		"ContainsAll(1, 1, 4): not matched: []any{1, 2, 3}"+internal.DiffPrefix+
+			"\t\texpected[1]: no element matched: 1\n"+
+			"\t\texpected[2]: no element matched: 4\n")
+}
+
+func (s *ElementsSuite) TestSequence() {
+	s.S.Equal(`Sequence(1, Positive())`, match.Sequence(1, match.Positive()).String())
+
+	s.match(match.Sequence(), []int{})
+	s.match(match.Sequence(1, 3), []int{1, 2, 3})
+	s.match(match.Sequence(1, match.Positive(), 1), []int{1, 0, 5, 2, 1})
+
+	ch := make(chan string, 3)
+	ch <- "much"
+	ch <- "such"
+	ch <- "wow"
+	s.match(match.Sequence("much", "wow"), ch)
+	s.match(match.Not(match.Sequence("wow", "much")), ch)
+	s.S.Len(3, ch)
+	s.S.Equal("much", <-ch)
+
+	s.matchDiff(match.Sequence(3, 1), []int{1, 2, 3},
+		"Sequence(3, 1): not matched: []any{1, 2, 3}"+internal.DiffPrefix+
+			"\t\texpected[1]: no element matched after previous match: 1\n")
+	s.matchDiff(match.Sequence(4), []int{1, 2, 3},
+		"Sequence(4): not matched: []any{1, 2, 3}"+internal.DiffPrefix+
+			"\t\texpected[0]: no element matched: 4\n")
+}
+
+func (s *ElementsSuite) TestSorted() {
+	byLen := func(a, b string) bool { return len(a) < len(b) }
+
+	s.S.Equal("Sorted()", match.Sorted(nil).String())
+	s.S.Equal("Sorted(func(string, string) bool)", match.Sorted(byLen).String())
+
+	s.match(match.Sorted(nil), []int{})
+	s.match(match.Sorted(nil), []int{1, 1, 2, 5})
+	s.match(match.Sorted(nil), []string{"a", "b"})
+	s.match(match.Sorted(nil), map[string]float64{"a": 1.5, "b": 2})
+	s.match(match.Sorted(byLen), []string{"z", "ab", "much"})
+	s.match(match.Sorted(func(a, b any) bool { return false }), []any{1, "a", nil})
+
+	s.match(match.Sorted(nil), []int{1, 3, 2}, "Sorted(): not sorted at index 2: 3 before 2: []any{1, 3, 2}")
+	s.match(match.Sorted(byLen), []string{"much", "z"},
+		`Sorted(func(string, string) bool): not sorted at index 1: "much" before "z": []any{"much", "z"}`)
+	s.match(match.Sorted(nil), []any{1, "a"}, `Sorted(): not comparable: "a", 1: []any{1, "a"}`)
+	s.match(match.Sorted(byLen), []int{1, 2},
+		"Sorted(func(string, string) bool): element not assignable to string: 2: []any{1, 2}")
+	s.match(match.Sorted(123), []int{1}, "Invalid Sorted(): lessFn must be nil or a func(a, b T) bool. Parameters: [123]")
+}
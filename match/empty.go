@@ -0,0 +1,73 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+)
+
+func Nil() Matcher {
+	return emptyMatcher{name: "Nil", desc: "not nil", fn: isNil}
+}
+
+func Empty() Matcher {
+	return emptyMatcher{name: "Empty", desc: "not empty", fn: isEmpty}
+}
+
+func Zero() Matcher {
+	return emptyMatcher{name: "Zero", desc: "not zero", fn: isZero}
+}
+
+type emptyMatcher struct {
+	name string
+	desc string
+	fn   func(reflect.Value) bool
+}
+
+func (m emptyMatcher) Matches(actual any) (ok bool, desc string) {
+	if m.fn(reflectV(actual)) {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("%s: %s: %s", m.String(), m.desc, formatValue(actual))
+}
+
+func (m emptyMatcher) String() string {
+	return m.name + "()"
+}
+
+func isNil(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+
+	switch value.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice,
+		reflect.UnsafePointer:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
+func isEmpty(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+
+	switch value.Kind() {
+	case reflect.Chan, reflect.Map, reflect.Slice:
+		return value.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return true
+		}
+
+		return isEmpty(value.Elem())
+	default:
+		return value.IsZero()
+	}
+}
+
+func isZero(value reflect.Value) bool {
+	return !value.IsValid() || value.IsZero()
+}
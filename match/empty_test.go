@@ -0,0 +1,55 @@
+package match_test
+
+import (
+	"testing"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestEmptySuite(t *testing.T) {
+	muchtest.Run(t, new(EmptySuite))
+}
+
+type EmptySuite struct {
+	pkgSuite
+}
+
+func (s *EmptySuite) TestNil() {
+	s.S.Equal("Nil()", match.Nil().String())
+
+	s.match(match.Nil(), nil)
+	s.match(match.Nil(), (*stringer)(nil))
+	s.match(match.Nil(), []int(nil))
+	s.match(match.Nil(), map[int]int(nil))
+	s.match(match.Nil(), error(nil))
+	s.match(match.Nil(), []int{}, "Nil(): not nil: []int{}")
+	s.match(match.Nil(), 0, "Nil(): not nil: 0")
+	s.match(match.Nil(), &stringer{}, "Nil(): not nil: *match_test.stringer()")
+}
+
+func (s *EmptySuite) TestEmpty() {
+	s.S.Equal("Empty()", match.Empty().String())
+
+	for _, actual := range []any{nil, "", 0, false, []int{}, map[string]int{}, make(chan int), (*int)(nil), new(int),
+		struct{}{}, [2]int{}} {
+		s.match(match.Empty(), actual)
+	}
+
+	s.match(match.Empty(), "Much", `Empty(): not empty: "Much"`)
+	s.match(match.Empty(), []int{0}, "Empty(): not empty: []int{0}")
+	s.match(match.Empty(), map[string]int{"": 0}, `Empty(): not empty: map[string]int{"":0}`)
+	s.match(match.Empty(), stringer{Text: "Much"}, "Empty(): not empty: match_test.stringer(Much)")
+}
+
+func (s *EmptySuite) TestZero() {
+	s.S.Equal("Zero()", match.Zero().String())
+
+	for _, actual := range []any{nil, "", 0, 0.0, false, []int(nil), (*int)(nil), stringer{}} {
+		s.match(match.Zero(), actual)
+	}
+
+	s.match(match.Zero(), []int{}, "Zero(): not zero: []int{}")
+	s.match(match.Zero(), new(int), "Zero(): not zero: *0")
+	s.match(match.Zero(), 1, "Zero(): not zero: 1")
+}
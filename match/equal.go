@@ -3,8 +3,11 @@ package match
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/grongor/go-muchtest/internal"
 )
 
 func Equal(expected any, options ...cmp.Option) Matcher {
@@ -24,164 +27,301 @@ type equalMatcher struct {
 	options cmp.Options
 }
 
+type equalDiff struct {
+	paths []string
+	descs []string
+}
+
+func (d *equalDiff) add(path string, format string, args ...any) bool {
+	if d != nil {
+		d.paths = append(d.paths, path)
+		d.descs = append(d.descs, fmt.Sprintf(format, args...))
+	}
+
+	return false
+}
+
+func (d *equalDiff) mismatch(path string, vEqual, vActual reflect.Value) bool {
+	if path == "" {
+		return false
+	}
+
+	return d.add(path, "expected %s, got %s", formatReflectValue(vEqual), formatReflectValue(vActual))
+}
+
+func (d *equalDiff) String() string {
+	if len(d.paths) == 0 {
+		return ""
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(internal.DiffPrefix)
+
+	for i, path := range d.paths {
+		builder.WriteString("\t\t")
+
+		if path != "" {
+			builder.WriteString(path)
+			builder.WriteString(": ")
+		}
+
+		builder.WriteString(d.descs[i])
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
+
 func (m equalMatcher) Matches(actual any) (ok bool, desc string) {
+	diff := &equalDiff{}
+
 	defer func() {
 		if recover() == nil && ok {
 			return
 		}
 
 		ok = false
-		desc = fmt.Sprintf("%s: not equal: %s%s", m.String(), formatValue(actual), getDiff(m.equal, actual, m.options))
+		desc = fmt.Sprintf("%s: not equal: %s%s", m.String(), formatValue(actual), diff.String())
 	}()
 
-	ok = m.doMatches(reflectV(m.equal), reflectV(actual))
+	ok = m.doMatches(diff, "", reflectV(m.equal), reflectV(actual))
 
 	return
 }
 
-func (m equalMatcher) doMatches(vEqual, vActual reflect.Value) bool {
+func (m equalMatcher) doMatches(diff *equalDiff, path string, vEqual, vActual reflect.Value) bool {
 	vEqual = indirect(vEqual)
 	vActual = indirect(vActual)
 
-	if !vEqual.IsValid() || !vActual.IsValid() {
-		return false
+	if matcher, ok := asMatcher(vEqual); ok {
+		return m.delegate(diff, path, matcher, vActual)
+	}
+
+	if equalNil, actualNil := isNilReference(vEqual), isNilReference(vActual); equalNil || actualNil {
+		if equalNil && actualNil {
+			return true
+		}
+
+		return diff.mismatch(path, vEqual, vActual)
 	}
 
 	if vActual.Kind() == reflect.Pointer {
-		return m.doMatches(vEqual, vActual.Elem())
+		return m.doMatches(diff, path, vEqual, vActual.Elem())
 	}
 
+	var equal bool
+
 	switch vEqual.Kind() {
 	case reflect.String:
-		if vActual.Kind() != reflect.String {
-			if vActual.CanInterface() {
-				return vEqual.String() == fmt.Sprint(vActual.Interface())
-			}
-
-			if vActual.Type().Implements(reflectT(new(fmt.Stringer)).Elem()) {
-				stringResult := vActual.MethodByName("String").Call(nil)
-
-				return fmt.Sprint(vEqual.Interface()) == stringResult[0].Interface().(string)
-			}
-
-			return false
-		}
-
-		return vEqual.String() == vActual.String()
+		equal = m.matchString(vEqual, vActual)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
 		reflect.Float32, reflect.Float64:
-		switch vActual.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
-			reflect.Float32, reflect.Float64:
-			ok, _ := Between(vEqual, vEqual).Matches(vActual)
-
-			return ok
-		case reflect.String:
-			a := fmt.Sprint(vEqual.Interface())
-			b := vActual.String()
-			eq := a == b
-			return eq
-		default:
-			if vActual.Type().Implements(reflectT(new(fmt.Stringer)).Elem()) {
-				if vActual.CanInterface() {
-					return fmt.Sprint(noStringer{v: vEqual.Interface()}) == fmt.Sprint(vActual.Interface())
-				}
-
-				stringResult := vActual.MethodByName("String").Call(nil)
-
-				return fmt.Sprint(noStringer{v: vEqual.Interface()}) == stringResult[0].Interface().(string)
-			}
-
-			return false
-		}
+		equal = m.matchNumber(vEqual, vActual)
 	case reflect.Complex64, reflect.Complex128:
 		if !vActual.CanComplex() {
-			return false
+			break
 		}
 
 		if vEqual.CanInterface() && vActual.CanInterface() {
-			return fmt.Sprint(vEqual.Interface()) == fmt.Sprint(vActual.Interface())
+			equal = fmt.Sprint(vEqual.Interface()) == fmt.Sprint(vActual.Interface())
+		} else {
+			equal = fmt.Sprint(vEqual.Complex()) == fmt.Sprint(vActual.Complex())
 		}
-
-		return fmt.Sprint(vEqual.Complex()) == fmt.Sprint(vActual.Complex())
 	case reflect.Slice, reflect.Array:
-		return m.matchSlice(vEqual, vActual)
+		return m.matchSlice(diff, path, vEqual, vActual)
 	case reflect.Map:
-		return m.matchMap(vEqual, vActual)
+		return m.matchMap(diff, path, vEqual, vActual)
 	case reflect.Struct:
-		return m.matchStruct(vEqual, vActual)
+		return m.matchStruct(diff, path, vEqual, vActual)
 	case reflect.Pointer:
-		if vActual.Kind() == reflect.Pointer {
-			return m.doMatches(vEqual.Elem(), vActual.Elem())
-		}
-
-		return m.doMatches(vEqual.Elem(), vActual)
+		return m.doMatches(diff, path, vEqual.Elem(), vActual)
 	default:
-		return cmp.Equal(vEqual.Interface(), vActual.Interface(), m.options...)
+		equal = cmp.Equal(vEqual.Interface(), vActual.Interface(), m.options...)
+	}
+
+	if !equal {
+		return diff.mismatch(path, vEqual, vActual)
 	}
+
+	return true
 }
 
-func (m equalMatcher) matchSlice(vEqual, vActual reflect.Value) bool {
-	expectedLength := vEqual.Len()
-	if vActual.Kind() != reflect.Slice || expectedLength != vActual.Len() {
+// isNilReference reports whether the value is nil itself or a nil pointer or interface, so that untyped and typed nils
+// are equal to each other.
+func isNilReference(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+
+	switch value.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		return value.IsNil()
+	default:
 		return false
 	}
+}
 
-	for i := 0; i < expectedLength; i++ {
-		if !m.doMatches(vEqual.Index(i), vActual.Index(i)) {
-			return false
+func (m equalMatcher) delegate(diff *equalDiff, path string, matcher Matcher, vActual reflect.Value) bool {
+	var actual any
+
+	if vActual.IsValid() {
+		if vActual.CanInterface() {
+			actual = vActual.Interface()
+		} else {
+			actual = vActual
 		}
 	}
 
+	if ok, desc := matcher.Matches(actual); !ok {
+		return diff.add(path, "%s", desc)
+	}
+
 	return true
 }
 
-func (m equalMatcher) getMapValue(vEqualKey, vActual reflect.Value) (vActualValue reflect.Value) {
-	defer func() {
-		if recover() == nil && vActualValue.IsValid() {
-			return
-		}
+func (m equalMatcher) matchString(vEqual, vActual reflect.Value) bool {
+	if vActual.Kind() == reflect.String {
+		return vEqual.String() == vActual.String()
+	}
+
+	if vActual.CanInterface() {
+		return vEqual.String() == fmt.Sprint(vActual.Interface())
+	}
+
+	if vActual.Type().Implements(reflectT(new(fmt.Stringer)).Elem()) {
+		stringResult := vActual.MethodByName("String").Call(nil)
+
+		return fmt.Sprint(vEqual.Interface()) == stringResult[0].Interface().(string)
+	}
 
-		for _, vActualKey := range vActual.MapKeys() {
-			if m.doMatches(vEqualKey, vActualKey) {
-				vActualValue = vActual.MapIndex(vActualKey)
+	return false
+}
+
+func (m equalMatcher) matchNumber(vEqual, vActual reflect.Value) bool {
+	switch vActual.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		ok, _ := Between(vEqual, vEqual).Matches(vActual)
 
-				return
+		return ok
+	case reflect.String:
+		return fmt.Sprint(vEqual.Interface()) == vActual.String()
+	default:
+		if vActual.Type().Implements(reflectT(new(fmt.Stringer)).Elem()) {
+			if vActual.CanInterface() {
+				return fmt.Sprint(noStringer{v: vEqual.Interface()}) == fmt.Sprint(vActual.Interface())
 			}
+
+			stringResult := vActual.MethodByName("String").Call(nil)
+
+			return fmt.Sprint(noStringer{v: vEqual.Interface()}) == stringResult[0].Interface().(string)
 		}
-	}()
 
-	return vActual.MapIndex(vEqualKey)
+		return false
+	}
 }
 
-func (m equalMatcher) matchMap(vEqual, vActual reflect.Value) bool {
-	if vActual.Kind() != reflect.Map || vEqual.Len() != vActual.Len() {
+func (m equalMatcher) matchSlice(diff *equalDiff, path string, vEqual, vActual reflect.Value) bool {
+	if vActual.Kind() != reflect.Slice && vActual.Kind() != reflect.Array {
+		return diff.mismatch(path, vEqual, vActual)
+	}
+
+	equalLength, actualLength := vEqual.Len(), vActual.Len()
+	if diff == nil && equalLength != actualLength {
 		return false
 	}
 
-	vEqualKeys := vEqual.MapKeys()
+	ok := true
+
+	for i := 0; i < equalLength || i < actualLength; i++ {
+		indexPath := fmt.Sprintf("%s[%d]", path, i)
+
+		switch {
+		case i >= actualLength:
+			ok = diff.add(indexPath, "missing value: %s", formatReflectValue(vEqual.Index(i)))
+		case i >= equalLength:
+			ok = diff.add(indexPath, "unexpected value: %s", formatReflectValue(vActual.Index(i)))
+		case !m.doMatches(diff, indexPath, vEqual.Index(i), vActual.Index(i)):
+			ok = false
+		}
 
-	if len(vEqualKeys) != vActual.Len() {
+		if !ok && diff == nil {
+			return false
+		}
+	}
+
+	return ok
+}
+
+func (m equalMatcher) matchMap(diff *equalDiff, path string, vEqual, vActual reflect.Value) bool {
+	if vActual.Kind() != reflect.Map {
+		return diff.mismatch(path, vEqual, vActual)
+	}
+
+	if diff == nil && vEqual.Len() != vActual.Len() {
 		return false
-	} else if len(vEqualKeys) == 0 {
-		return true
 	}
 
-	for _, vEqualKey := range vEqualKeys {
-		if !m.doMatches(vEqual.MapIndex(vEqualKey), m.getMapValue(vEqualKey, vActual)) {
+	vActualKeys := sortedMapKeys(vActual)
+	used := make([]bool, len(vActualKeys))
+	ok := true
+
+	for _, vEqualKey := range sortedMapKeys(vEqual) {
+		keyPath := fmt.Sprintf("%s[%s]", path, formatReflectValue(vEqualKey))
+
+		i := m.findMapKey(vEqualKey, vActualKeys, used)
+		if i == -1 {
+			ok = diff.add(keyPath, "missing key with value: %s", formatReflectValue(vEqual.MapIndex(vEqualKey)))
+		} else {
+			used[i] = true
+
+			if !m.doMatches(diff, keyPath, vEqual.MapIndex(vEqualKey), vActual.MapIndex(vActualKeys[i])) {
+				ok = false
+			}
+		}
+
+		if !ok && diff == nil {
 			return false
 		}
 	}
 
-	return true
+	for i, vActualKey := range vActualKeys {
+		if used[i] {
+			continue
+		}
+
+		keyPath := fmt.Sprintf("%s[%s]", path, formatReflectValue(vActualKey))
+		ok = diff.add(keyPath, "unexpected key with value: %s", formatReflectValue(vActual.MapIndex(vActualKey)))
+	}
+
+	return ok
 }
 
-func (m equalMatcher) matchStruct(vEqual, vActual reflect.Value) bool {
+func (m equalMatcher) findMapKey(vEqualKey reflect.Value, vActualKeys []reflect.Value, used []bool) int {
+	if vEqualKey.CanInterface() {
+		for i, vActualKey := range vActualKeys {
+			if !used[i] && vActualKey.CanInterface() && vActualKey.Type() == vEqualKey.Type() &&
+				vActualKey.Interface() == vEqualKey.Interface() {
+				return i
+			}
+		}
+	}
+
+	for i, vActualKey := range vActualKeys {
+		if !used[i] && m.doMatches(nil, "", vEqualKey, vActualKey) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (m equalMatcher) matchStruct(diff *equalDiff, path string, vEqual, vActual reflect.Value) bool {
 	fieldsCount := vEqual.NumField()
 	if vActual.Kind() != reflect.Struct || fieldsCount != vActual.NumField() {
-		return false
+		return diff.mismatch(path, vEqual, vActual)
 	}
 
 	tEqual := vEqual.Type()
@@ -189,17 +329,54 @@ func (m equalMatcher) matchStruct(vEqual, vActual reflect.Value) bool {
 
 	for i := 0; i < fieldsCount; i++ {
 		if tEqual.Field(i).Name != tActual.Field(i).Name {
-			return false
+			return diff.mismatch(path, vEqual, vActual)
 		}
+	}
 
-		if !m.doMatches(vEqual.Field(i), vActual.Field(i)) {
-			return false
+	ok := true
+
+	for i := 0; i < fieldsCount; i++ {
+		if !m.doMatches(diff, path+"."+tEqual.Field(i).Name, vEqual.Field(i), vActual.Field(i)) {
+			if ok = false; diff == nil {
+				return false
+			}
 		}
 	}
 
-	return true
+	return ok
 }
 
 func (m equalMatcher) String() string {
 	return fmt.Sprintf("Equal(%s)", formatValue(m.equal))
 }
+
+func sortedMapKeys(vMap reflect.Value) []reflect.Value {
+	vKeys := vMap.MapKeys()
+	keys := make([]string, len(vKeys))
+
+	for i, vKey := range vKeys {
+		keys[i] = formatReflectValue(vKey)
+	}
+
+	sort.Sort(keySorter{vKeys: vKeys, keys: keys})
+
+	return vKeys
+}
+
+type keySorter struct {
+	vKeys []reflect.Value
+	keys  []string
+}
+
+func (s keySorter) Len() int {
+	return len(s.keys)
+}
+
+func (s keySorter) Less(i, j int) bool {
+	return s.keys[i] < s.keys[j]
+}
+
+func (s keySorter) Swap(i, j int) {
+	s.vKeys[i], s.vKeys[j] = s.vKeys[j], s.vKeys[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
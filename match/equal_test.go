@@ -166,6 +166,13 @@ func (s *EqualSuite) TestEqual() {
 			},
 		},
 
+		{name: "nil", a: nil, b: nil},
+		{name: "nil and nil pointer", a: nil, b: (*int)(nil)},
+		{name: "nil pointer fields", a: struct{ P *int }{}, b: struct{ P *int }{}},
+		{name: "nil interface fields", a: struct{ E error }{}, b: struct{ E error }{}},
+		{name: "nil any fields", a: struct{ A any }{}, b: struct{ A any }{}},
+		{name: "nil pointer field and nil any", a: struct{ A any }{A: (*int)(nil)}, b: struct{ A any }{}},
+
 		// not matching
 		{name: "invalid equal", a: "Much", b: nil, desc: `Equal("Much"): not equal: nil`},
 		{name: "invalid actual", a: nil, b: "Much", desc: `Equal(nil): not equal: "Much"`},
@@ -278,3 +285,121 @@ func (s *EqualSuite) TestEqual() {
 		})
 	}
 }
+
+func (s *EqualSuite) TestEqual_Diff() {
+	type otherString string
+
+	type address struct {
+		City, Zip string
+	}
+
+	type user struct {
+		Name    string
+		Address *address
+		Tags    []string
+	}
+
+	for _, test := range []struct {
+		name string
+		a, b any
+		diff []string
+	}{
+		{name: "scalar", a: 123, b: 456},
+		{name: "slice", a: []int{1, 2, 3}, b: []int{1, 5}, diff: []string{
+			"[1]: expected 2, got 5",
+			"[2]: missing value: 3",
+		}},
+		{name: "slice, unexpected", a: []any{"1"}, b: []int{1, 2}, diff: []string{
+			"[1]: unexpected value: 2",
+		}},
+		{
+			name: "map",
+			a:    map[string]any{"a": 1, "b": []int{1}, "d": 4},
+			b:    map[otherString]any{"a": 2, "b": []int16{1, 2}, "c": 3},
+			diff: []string{
+				`["a"]: expected 1, got 2`,
+				`["b"][1]: unexpected value: 2`,
+				`["d"]: missing key with value: 4`,
+				`["c"]: unexpected key with value: 3`,
+			},
+		},
+		{
+			name: "struct",
+			a:    user{Name: "Much", Address: &address{City: "Prague", Zip: "110 00"}, Tags: []string{"a"}},
+			b:    &user{Name: "Much", Address: &address{City: "Brno", Zip: "110 00"}},
+			diff: []string{
+				`.Address.City: expected "Prague", got "Brno"`,
+				`.Tags[0]: missing value: "a"`,
+			},
+		},
+		{
+			name: "struct, unexported",
+			a:    unexported{number: 123, stringer: &stringer{Text: "Much"}},
+			b:    unexported{number: 123, stringer: &stringer{Text: "Such"}},
+			diff: []string{`.stringer.Text: expected "Much", got "Such"`},
+		},
+		{
+			name: "struct, different type",
+			a:    []any{user{Name: "Much"}},
+			b:    []any{address{City: "Much"}},
+			diff: []string{`[0]: expected match_test.user{Name:"Much"}, got match_test.address{City:"Much"}`},
+		},
+		{name: "nil", a: map[string]any{"a": 1}, b: map[string]any{"a": nil}, diff: []string{`["a"]: expected 1, got nil`}},
+		{
+			name: "nil pointer",
+			a:    struct{ P *int }{},
+			b:    struct{ P *int }{P: new(int)},
+			diff: []string{`.P: expected (*int)(nil), got *0`},
+		},
+	} {
+		s.Run(test.name, func() {
+			ok, desc := match.Equal(test.a).Matches(test.b)
+			s.S.False(ok)
+
+			header := internal.TrimDiff(desc)
+			if len(test.diff) == 0 {
+				s.S.Equal(header, desc)
+
+				return
+			}
+
+			s.S.Equal(header+internal.DiffPrefix+"\t\t"+strings.Join(test.diff, "\n\t\t")+"\n", desc)
+		})
+	}
+}
+
+func (s *EqualSuite) TestEqual_NestedMatchers() {
+	expected := map[string]any{
+		"id":    match.Regexp(`^\d+$`),
+		"name":  "Much",
+		"tags":  []any{"much", match.Prefix("te")},
+		"score": match.Between(1, 2),
+	}
+
+	s.match(match.Equal(expected), map[string]any{
+		"id":    "123",
+		"name":  "Much",
+		"tags":  []string{"much", "test"},
+		"score": 1.5,
+	})
+	s.match(match.Equal(match.Any()), nil)
+	s.match(match.Equal([]any{match.Nil()}), []any{nil})
+
+	ok, desc := match.Equal(expected).Matches(map[string]any{
+		"id":    "abc",
+		"name":  "Much",
+		"tags":  []string{"much", "much"},
+		"score": 1.5,
+	})
+	s.S.False(ok)
+	s.S.Equal(
+		internal.DiffPrefix+
+			"\t\t[\"id\"]: Regexp(`^\\d+$`): not matched: \"abc\"\n"+
+			"\t\t[\"tags\"][1]: Prefix(\"te\"): not prefixed: \"much\"\n",
+		desc[len(internal.TrimDiff(desc)):],
+	)
+
+	ok, desc = match.Equal(match.Len(2)).Matches([]int{1})
+	s.S.False(ok)
+	s.S.Equal("Equal(Len(2)): not equal: []int{1}"+internal.DiffPrefix+"\t\tLen(2): got 1: []int{1}\n", desc)
+}
@@ -0,0 +1,68 @@
+package match
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+func ErrorIs(target error) Matcher {
+	return errorMatcher{name: "ErrorIs", expected: target}
+}
+
+func ErrorAs(target any) Matcher {
+	t := reflectT(target)
+	if t == nil || t.Kind() != reflect.Pointer || reflectV(target).IsNil() {
+		return matcherErr("Invalid ErrorAs(): target must be a non-nil pointer.", target)
+	}
+
+	if t.Elem().Kind() != reflect.Interface && !t.Elem().Implements(reflectT(new(error)).Elem()) {
+		return matcherErr("Invalid ErrorAs(): *target must be interface or implement error.", target)
+	}
+
+	return errorMatcher{name: "ErrorAs", expected: target}
+}
+
+func ErrorMsg(expected any) Matcher {
+	return errorMatcher{name: "ErrorMsg", expected: expected}
+}
+
+type errorMatcher struct {
+	name     string
+	expected any
+}
+
+func (m errorMatcher) Matches(actual any) (ok bool, desc string) {
+	err, ok := actual.(error)
+	if !ok {
+		return false, fmt.Sprintf("%s: not an error: %s", m.String(), formatValue(actual))
+	}
+
+	switch m.name {
+	case "ErrorIs":
+		target, _ := m.expected.(error)
+		if errors.Is(err, target) {
+			return true, ""
+		}
+	case "ErrorAs":
+		if errors.As(err, m.expected) {
+			return true, ""
+		}
+	case "ErrorMsg":
+		if ok, _ = ToMatcher(m.expected).Matches(err.Error()); ok {
+			return true, ""
+		}
+
+		return false, fmt.Sprintf("%s: message not matched: %s", m.String(), formatValue(err.Error()))
+	}
+
+	return false, fmt.Sprintf("%s: not in chain: %s", m.String(), formatValue(actual))
+}
+
+func (m errorMatcher) String() string {
+	if m.name == "ErrorAs" {
+		return fmt.Sprintf("ErrorAs(%s)", stringAny(reflectT(m.expected).Elem().String()))
+	}
+
+	return fmt.Sprintf("%s(%s)", m.name, formatValue(m.expected))
+}
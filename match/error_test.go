@@ -0,0 +1,62 @@
+package match_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestErrorSuite(t *testing.T) {
+	muchtest.Run(t, new(ErrorSuite))
+}
+
+type ErrorSuite struct {
+	pkgSuite
+}
+
+func (s *ErrorSuite) TestErrorIs() {
+	err := fmt.Errorf("much: %w", io.EOF)
+
+	s.S.Equal("ErrorIs(*errors.errorString(EOF))", match.ErrorIs(io.EOF).String())
+
+	s.match(match.ErrorIs(io.EOF), err)
+	s.match(match.ErrorIs(io.EOF), io.EOF)
+	s.match(match.ErrorIs(nil), nil, "ErrorIs(nil): not an error: nil")
+	s.match(match.ErrorIs(io.ErrUnexpectedEOF), err,
+		"ErrorIs(*errors.errorString(unexpected EOF)): not in chain: *fmt.wrapError(much: EOF)")
+	s.match(match.ErrorIs(io.EOF), "EOF", `ErrorIs(*errors.errorString(EOF)): not an error: "EOF"`)
+}
+
+func (s *ErrorSuite) TestErrorAs() {
+	var pathErr *fs.PathError
+
+	err := fmt.Errorf("much: %w", &fs.PathError{Op: "open", Path: "/much", Err: io.EOF})
+
+	s.S.Equal("ErrorAs(*fs.PathError)", match.ErrorAs(&pathErr).String())
+
+	s.match(match.ErrorAs(&pathErr), err)
+	s.S.Equal("/much", pathErr.Path)
+
+	s.match(match.ErrorAs(&pathErr), io.EOF, "ErrorAs(*fs.PathError): not in chain: *errors.errorString(EOF)")
+	s.match(match.ErrorAs(nil), err, "Invalid ErrorAs(): target must be a non-nil pointer. Parameters: [<nil>]")
+	s.matchFn(match.ErrorAs(new(int)), err, func(desc string) {
+		s.S.Regexp(`^Invalid ErrorAs\(\): \*target must be interface or implement error\.`, desc)
+	})
+}
+
+func (s *ErrorSuite) TestErrorMsg() {
+	err := errors.New("much test")
+
+	s.S.Equal(`ErrorMsg("much test")`, match.ErrorMsg("much test").String())
+
+	s.match(match.ErrorMsg("much test"), err)
+	s.match(match.ErrorMsg(match.Contains("much")), err)
+	s.match(match.ErrorMsg(match.Prefix("test")), err,
+		`ErrorMsg(Prefix("test")): message not matched: "much test"`)
+	s.match(match.ErrorMsg("much"), nil, `ErrorMsg("much"): not an error: nil`)
+}
@@ -0,0 +1,239 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var formatVerbRegexp = regexp.MustCompile(`%([-+# 0]*)(\d*)(?:\.\d+)?([a-zA-Z%])`)
+
+func Format(template string, args ...any) Matcher {
+	builder := strings.Builder{}
+	builder.WriteByte('^')
+
+	var verbs []byte
+
+	last := 0
+
+	for _, loc := range formatVerbRegexp.FindAllStringSubmatchIndex(template, -1) {
+		builder.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		last = loc[1]
+
+		verb := template[loc[6]]
+		if verb == '%' {
+			builder.WriteString("%")
+
+			continue
+		}
+
+		pattern, ok := formatVerbPatterns[verb]
+		if !ok {
+			return matcherErr(fmt.Sprintf("Invalid Format(%q): unsupported verb %%%c", template, verb))
+		}
+
+		flags := template[loc[2]:loc[3]]
+		width := loc[5] > loc[4]
+		leftAligned := strings.Contains(flags, "-")
+
+		if width && !leftAligned {
+			// Width pads the value with spaces (or zeros, which the patterns already allow).
+			builder.WriteString(` *`)
+		}
+
+		if strings.Contains(flags, " ") && strings.IndexByte("dxXfge", verb) != -1 {
+			// The space flag leaves a space in place of the plus sign.
+			builder.WriteString(` ?`)
+		}
+
+		builder.WriteString(pattern)
+
+		if width && leftAligned {
+			builder.WriteString(` *`)
+		}
+
+		verbs = append(verbs, verb)
+	}
+
+	builder.WriteString(regexp.QuoteMeta(template[last:]))
+	builder.WriteByte('$')
+
+	if len(args) != 0 && len(args) != len(verbs) {
+		return matcherErr(fmt.Sprintf("Invalid Format(%q): template has %d verbs, got %d args", template, len(verbs), len(args)))
+	}
+
+	return formatMatcher{
+		template: template,
+		regexp:   regexp.MustCompile(builder.String()),
+		verbs:    verbs,
+		args:     args,
+	}
+}
+
+var formatVerbPatterns = map[byte]string{
+	'v': `(.*?)`,
+	's': `(.*?)`,
+	'q': `("(?:[^"\\]|\\.)*"|` + "`[^`]*`)",
+	'd': `([+-]?\d+)`,
+	'x': `([+-]?(?:0x)?[0-9a-f]+)`,
+	'X': `([+-]?(?:0X)?[0-9A-F]+)`,
+	'f': `([+-]?(?:\d+(?:\.\d*)?|NaN|Inf))`,
+	'g': `([+-]?(?:\d+(?:\.\d*)?(?:e[+-]\d+)?|NaN|Inf))`,
+	'e': `([+-]?(?:\d+(?:\.\d*)?e[+-]\d+|NaN|Inf))`,
+	't': `(true|false)`,
+}
+
+type formatMatcher struct {
+	template     string
+	regexp       *regexp.Regexp
+	verbs        []byte
+	args         []any
+	captureLater bool
+}
+
+// CaptureLater postpones capturing the args of a Format() matcher: the returned matcher doesn't capture them, the
+// returned func does. It's meant for callers that match the value as a part of something bigger, so that the capture
+// pointers are written only once all of it has matched. Other matchers are returned as they are.
+func CaptureLater(matcher Matcher) (Matcher, func(actual any)) {
+	m, ok := matcher.(formatMatcher)
+	if !ok || len(m.args) == 0 {
+		return matcher, func(any) {}
+	}
+
+	m.captureLater = true
+
+	return m, m.capture
+}
+
+func (m formatMatcher) Matches(actual any) (ok bool, desc string) {
+	if ok, desc = m.match(actual); ok && !m.captureLater {
+		m.capture(actual)
+	}
+
+	return ok, desc
+}
+
+func (m formatMatcher) match(actual any) (bool, string) {
+	str := fmt.Sprint(actual)
+
+	submatches := m.regexp.FindStringSubmatch(str)
+	if submatches == nil {
+		return false, fmt.Sprintf("%s: not matched: %s", m.String(), formatValue(actual))
+	}
+
+	if len(m.args) == 0 {
+		return true, ""
+	}
+
+	diff := &equalDiff{}
+
+	for i, arg := range m.args {
+		value, ok := parseFormatArg(m.verbs[i], submatches[i+1])
+		if !ok {
+			diff.add(fmt.Sprintf("args[%d]", i), "can't parse %%%c: %q", m.verbs[i], submatches[i+1])
+
+			continue
+		}
+
+		if _, isCapture := formatCapture(arg, value); isCapture {
+			continue
+		}
+
+		if ok, desc := ToMatcher(arg).Matches(value); !ok {
+			diff.add(fmt.Sprintf("args[%d]", i), "%s", desc)
+		}
+	}
+
+	if len(diff.paths) == 0 {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("%s: not matched: %s%s", m.String(), formatValue(actual), diff.String())
+}
+
+func (m formatMatcher) String() string {
+	if len(m.args) == 0 {
+		return fmt.Sprintf("Format(%q)", m.template)
+	}
+
+	args := make([]string, len(m.args))
+	for i, arg := range m.args {
+		if _, isMatcher := arg.(Matcher); !isMatcher && reflectV(arg).Kind() == reflect.Pointer {
+			args[i] = fmt.Sprintf("%T", arg)
+
+			continue
+		}
+
+		args[i] = ToMatcher(arg).String()
+	}
+
+	return fmt.Sprintf("Format(%q, %s)", m.template, strings.Join(args, ", "))
+}
+
+func parseFormatArg(verb byte, str string) (any, bool) {
+	switch verb {
+	case 'd':
+		i, err := strconv.Atoi(str)
+
+		return i, err == nil
+	case 'x', 'X':
+		digits := strings.TrimLeft(str, "+-")
+		digits = strings.TrimPrefix(strings.TrimPrefix(digits, "0x"), "0X")
+
+		i, err := strconv.ParseInt(digits, 16, 64)
+		if strings.HasPrefix(str, "-") {
+			i = -i
+		}
+
+		return int(i), err == nil
+	case 'f', 'g', 'e':
+		f, err := strconv.ParseFloat(str, 64)
+
+		return f, err == nil
+	case 't':
+		return str == "true", true
+	case 'q':
+		s, err := strconv.Unquote(str)
+
+		return s, err == nil
+	default:
+		return str, true
+	}
+}
+
+// capture stores the args of the matched value into the capture pointers.
+func (m formatMatcher) capture(actual any) {
+	submatches := m.regexp.FindStringSubmatch(fmt.Sprint(actual))
+	if submatches == nil {
+		return
+	}
+
+	for i, arg := range m.args {
+		value, ok := parseFormatArg(m.verbs[i], submatches[i+1])
+		if !ok {
+			continue
+		}
+
+		if vElem, isCapture := formatCapture(arg, value); isCapture {
+			vElem.Set(reflectV(value))
+		}
+	}
+}
+
+// formatCapture returns the element the arg points to, if it's a pointer the value may be captured into.
+func formatCapture(arg, value any) (reflect.Value, bool) {
+	if _, isMatcher := arg.(Matcher); isMatcher {
+		return reflect.Value{}, false
+	}
+
+	vArg := reflectV(arg)
+	if vArg.Kind() != reflect.Pointer || vArg.IsNil() {
+		return reflect.Value{}, false
+	}
+
+	vElem := vArg.Elem()
+
+	return vElem, reflectT(value).AssignableTo(vElem.Type())
+}
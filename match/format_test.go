@@ -0,0 +1,85 @@
+package match_test
+
+import (
+	"testing"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestFormatSuite(t *testing.T) {
+	muchtest.Run(t, new(FormatSuite))
+}
+
+type FormatSuite struct {
+	pkgSuite
+}
+
+func (s *FormatSuite) TestFormat() {
+	s.S.Equal(`Format("user %s failed %d times")`, match.Format("user %s failed %d times").String())
+	s.S.Equal(`Format("%s: %d", Prefix("do"), Greater(2))`,
+		match.Format("%s: %d", match.Prefix("do"), match.Greater(2)).String())
+
+	s.match(match.Format("user %s failed %d times"), "user doge failed 3 times")
+	s.match(match.Format("user %v failed %d times"), "user much doge failed -3 times")
+	s.match(match.Format("took %.2fs (%5.1f%%), ok: %t"), "took 1.25s (  9.5%), ok: true")
+	s.match(match.Format("got %q, %x"), `got "much \"wow\"", ff`)
+	s.match(match.Format("[much] %s"), "[much] wow")
+	s.match(match.Format("% d|%+d|%-4d|%04d|%#x|%X"), " 5|+5|5   |0005|0xff|-1F")
+	s.match(match.Format("user %s failed %d times"), "user doge failed many times",
+		`Format("user %s failed %d times"): not matched: "user doge failed many times"`)
+	s.match(match.Format("user %s"), "user doge!")
+	s.match(match.Format("user %s!"), "user doge",
+		`Format("user %s!"): not matched: "user doge"`)
+
+	s.match(match.Format("user %s failed %d times", "doge", 3), "user doge failed 3 times")
+	s.match(match.Format("%q: %f, %t", "much", match.InDelta(1.5, 0.1), true), `"much": 1.52, true`)
+	s.match(match.Format("id %x, code %#X, sign % d", 255, -31, 5), "id ff, code -0X1F, sign  5")
+	s.match(match.Format("user %s failed %d times", match.Prefix("cat"), match.Greater(3)), "user doge failed 3 times",
+		`Format("user %s failed %d times", Prefix("cat"), Greater(3)): not matched: "user doge failed 3 times"`)
+}
+
+func (s *FormatSuite) TestFormat_Capture() {
+	var user string
+	var times int
+
+	m := match.Format("user %s failed %d times", &user, &times)
+
+	s.S.Equal(`Format("user %s failed %d times", *string, *int)`, m.String())
+	s.match(m, "user doge failed 3 times")
+	s.S.Equal("doge", user)
+	s.S.Equal(3, times)
+
+	var id int
+
+	s.match(match.Format("id %x", &id), "id 1f")
+	s.S.Equal(31, id)
+
+	// Nothing is captured unless the whole value matched.
+	s.match(match.Format("user %s failed %d times", &user, 5), "user cat failed 3 times",
+		`Format("user %s failed %d times", *string, Equal(5)): not matched: "user cat failed 3 times"`)
+	s.S.Equal("doge", user)
+}
+
+func (s *FormatSuite) TestCaptureLater() {
+	var user string
+
+	m, capture := match.CaptureLater(match.Format("user %s", &user))
+
+	s.match(m, "user doge")
+	s.S.Empty(user)
+
+	capture("user doge")
+	s.S.Equal("doge", user)
+
+	m, capture = match.CaptureLater(match.Prefix("user"))
+	s.S.Equal(`Prefix("user")`, m.String())
+	capture("user doge")
+}
+
+func (s *FormatSuite) TestFormat_Invalid() {
+	s.match(match.Format("user %s failed %d times", "doge"), "user doge failed 3 times",
+		`Invalid Format("user %s failed %d times"): template has 2 verbs, got 1 args`)
+	s.match(match.Format("pointer %p"), "pointer 0x1",
+		`Invalid Format("pointer %p"): unsupported verb %p`)
+}
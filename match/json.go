@@ -0,0 +1,713 @@
+package match
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var jsonIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func JSON(expected any) Matcher {
+	var normalized any
+	var err error
+
+	text, isText := jsonText(expected)
+	if isText {
+		normalized, err = decodeJSON(text)
+	} else {
+		normalized, err = normalizeJSON(reflectV(expected))
+	}
+
+	if err != nil {
+		return matcherErr("Invalid JSON(): "+err.Error(), expected)
+	}
+
+	m := jsonMatcher{expected: normalized, str: formatValue(expected)}
+	if isText {
+		m.str = compactJSON(text)
+	}
+
+	return m
+}
+
+func JSONPath(path string, expected any) Matcher {
+	selectors, err := parseJSONPath(path)
+	if err != nil {
+		return matcherErr("Invalid JSONPath(): "+err.Error(), path)
+	}
+
+	m := jsonPathMatcher{path: path, selectors: selectors}
+
+	switch expected.(type) {
+	case Matcher, *regexp.Regexp:
+		m.matcher = ToMatcher(expected)
+	default:
+		if tExpected := reflectT(expected); tExpected != nil && tExpected.Kind() == reflect.Func {
+			m.matcher = ToMatcher(expected)
+
+			break
+		}
+
+		normalized, err := normalizeJSON(reflectV(expected))
+		if err != nil {
+			return matcherErr("Invalid JSONPath(): "+err.Error(), path, expected)
+		}
+
+		m.matcher = jsonMatcher{expected: normalized, str: formatValue(expected), root: path}
+	}
+
+	return m
+}
+
+type jsonMatcher struct {
+	expected any
+	str      string
+	root     string
+}
+
+func (m jsonMatcher) Matches(actual any) (ok bool, desc string) {
+	vActual, err := actualJSON(actual)
+	if err != nil {
+		return false, fmt.Sprintf("%s: invalid JSON: %s: %s", m.String(), err.Error(), formatValue(actual))
+	}
+
+	return m.matchDecoded(vActual)
+}
+
+func (m jsonMatcher) matchDecoded(vActual any) (ok bool, desc string) {
+	root := m.root
+	if root == "" {
+		root = "$"
+	}
+
+	diff := &equalDiff{}
+	if m.doMatches(diff, root, m.expected, vActual) {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("%s: not equal: %s%s", m.String(), formatJSON(vActual), diff.String())
+}
+
+func (m jsonMatcher) doMatches(diff *equalDiff, path string, expected, actual any) bool {
+	if matcher, ok := asMatcher(reflectV(expected)); ok {
+		if ok, desc := matcher.Matches(plainJSON(actual)); !ok {
+			return diff.add(path, "%s", desc)
+		}
+
+		return true
+	}
+
+	switch expected := expected.(type) {
+	case map[string]any:
+		actual, ok := actual.(map[string]any)
+		if !ok {
+			break
+		}
+
+		return m.matchObject(diff, path, expected, actual)
+	case []any:
+		actual, ok := actual.([]any)
+		if !ok {
+			break
+		}
+
+		return m.matchArray(diff, path, expected, actual)
+	case json.Number:
+		if actual, ok := actual.(json.Number); ok && compareJSONNumbers(expected, actual) {
+			return true
+		}
+	default:
+		if expected == actual {
+			return true
+		}
+	}
+
+	return diff.add(path, "expected %s, got %s", formatJSON(expected), formatJSON(actual))
+}
+
+func (m jsonMatcher) matchObject(diff *equalDiff, path string, expected, actual map[string]any) bool {
+	ok := true
+
+	for _, key := range sortedJSONKeys(expected) {
+		keyPath := jsonKeyPath(path, key)
+
+		vActual, found := actual[key]
+		if !found {
+			ok = diff.add(keyPath, "missing key with value: %s", formatJSON(expected[key]))
+		} else if !m.doMatches(diff, keyPath, expected[key], vActual) {
+			ok = false
+		}
+	}
+
+	for _, key := range sortedJSONKeys(actual) {
+		if _, found := expected[key]; !found {
+			ok = diff.add(jsonKeyPath(path, key), "unexpected key with value: %s", formatJSON(actual[key]))
+		}
+	}
+
+	return ok
+}
+
+func (m jsonMatcher) matchArray(diff *equalDiff, path string, expected, actual []any) bool {
+	ok := true
+
+	for i := 0; i < len(expected) || i < len(actual); i++ {
+		indexPath := fmt.Sprintf("%s[%d]", path, i)
+
+		switch {
+		case i >= len(actual):
+			ok = diff.add(indexPath, "missing value: %s", formatJSON(expected[i]))
+		case i >= len(expected):
+			ok = diff.add(indexPath, "unexpected value: %s", formatJSON(actual[i]))
+		case !m.doMatches(diff, indexPath, expected[i], actual[i]):
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+func (m jsonMatcher) String() string {
+	return fmt.Sprintf("JSON(%s)", m.str)
+}
+
+type jsonPathSelector struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+type jsonPathMatcher struct {
+	path      string
+	selectors []jsonPathSelector
+	matcher   Matcher
+}
+
+func (m jsonPathMatcher) Matches(actual any) (ok bool, desc string) {
+	vActual, err := actualJSON(actual)
+	if err != nil {
+		return false, fmt.Sprintf("%s: invalid JSON: %s: %s", m.String(), err.Error(), formatValue(actual))
+	}
+
+	values := []any{vActual}
+	wildcard := false
+	path := "$"
+
+	for _, selector := range m.selectors {
+		var selected []any
+
+		for _, value := range values {
+			selected = append(selected, selector.apply(value)...)
+		}
+
+		wildcard = wildcard || selector.wildcard
+		path = selector.appendTo(path)
+
+		if len(selected) == 0 && !wildcard {
+			return false, fmt.Sprintf("%s: path not found: %s: %s", m.String(), path, formatJSON(vActual))
+		}
+
+		values = selected
+	}
+
+	var value any = values
+
+	switch {
+	case !wildcard:
+		value = values[0]
+	case values == nil:
+		value = []any{}
+	}
+
+	if jsonMatcher, isJSON := m.matcher.(jsonMatcher); isJSON {
+		ok, desc = jsonMatcher.matchDecoded(value)
+	} else {
+		ok, desc = m.matcher.Matches(plainJSON(value))
+	}
+
+	if !ok {
+		return false, fmt.Sprintf("%s: not matched: %s", m.String(), desc)
+	}
+
+	return true, ""
+}
+
+func (m jsonPathMatcher) String() string {
+	return fmt.Sprintf("JSONPath(%s, %s)", strconv.Quote(m.path), m.matcher.String())
+}
+
+func (s jsonPathSelector) apply(value any) []any {
+	switch value := value.(type) {
+	case map[string]any:
+		if s.wildcard {
+			values := make([]any, 0, len(value))
+			for _, key := range sortedJSONKeys(value) {
+				values = append(values, value[key])
+			}
+
+			return values
+		}
+
+		if v, ok := value[s.key]; ok && !s.isIndex {
+			return []any{v}
+		}
+	case []any:
+		if s.wildcard {
+			return value
+		}
+
+		index := s.index
+		if index < 0 {
+			index += len(value)
+		}
+
+		if s.isIndex && index >= 0 && index < len(value) {
+			return []any{value[index]}
+		}
+	}
+
+	return nil
+}
+
+func (s jsonPathSelector) appendTo(path string) string {
+	switch {
+	case s.wildcard:
+		return path + "[*]"
+	case s.isIndex:
+		return fmt.Sprintf("%s[%d]", path, s.index)
+	default:
+		return jsonKeyPath(path, s.key)
+	}
+}
+
+func parseJSONPath(path string) ([]jsonPathSelector, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("path must start with $")
+	}
+
+	var selectors []jsonPathSelector
+
+	for rest := path[1:]; rest != ""; {
+		switch {
+		case strings.HasPrefix(rest, ".*"):
+			selectors = append(selectors, jsonPathSelector{wildcard: true})
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "."):
+			end := strings.IndexAny(rest[1:], ".[")
+			if end == -1 {
+				end = len(rest) - 1
+			}
+
+			if end == 0 {
+				return nil, fmt.Errorf("empty key at offset %d", len(path)-len(rest))
+			}
+
+			selectors = append(selectors, jsonPathSelector{key: rest[1 : end+1]})
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("unclosed bracket at offset %d", len(path)-len(rest))
+			}
+
+			selector, err := parseJSONPathBracket(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("%s at offset %d", err.Error(), len(path)-len(rest))
+			}
+
+			selectors = append(selectors, selector)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d", rest[0], len(path)-len(rest))
+		}
+	}
+
+	return selectors, nil
+}
+
+func parseJSONPathBracket(content string) (jsonPathSelector, error) {
+	if content == "*" {
+		return jsonPathSelector{wildcard: true}, nil
+	}
+
+	if strings.HasPrefix(content, "'") && strings.HasSuffix(content, "'") && len(content) > 1 {
+		return jsonPathSelector{key: content[1 : len(content)-1]}, nil
+	}
+
+	if strings.HasPrefix(content, `"`) {
+		key, err := strconv.Unquote(content)
+		if err != nil {
+			return jsonPathSelector{}, fmt.Errorf("invalid key %s", content)
+		}
+
+		return jsonPathSelector{key: key}, nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return jsonPathSelector{}, fmt.Errorf("invalid index %s", content)
+	}
+
+	return jsonPathSelector{index: index, isIndex: true}, nil
+}
+
+func jsonText(value any) ([]byte, bool) {
+	switch value := value.(type) {
+	case string:
+		return []byte(value), true
+	case []byte:
+		return value, true
+	case json.RawMessage:
+		return value, true
+	default:
+		return nil, false
+	}
+}
+
+func actualJSON(actual any) (any, error) {
+	if text, ok := jsonText(actual); ok {
+		return decodeJSON(text)
+	}
+
+	return normalizeJSON(reflectV(actual))
+}
+
+func decodeJSON(text []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(text))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after top-level value")
+	}
+
+	return value, nil
+}
+
+func normalizeJSON(value reflect.Value) (any, error) {
+	if matcher, ok := asMatcher(value); ok {
+		return matcher, nil
+	}
+
+	value = indirect(value)
+	if !value.IsValid() {
+		return nil, nil
+	}
+
+	if value.CanInterface() {
+		if _, ok := value.Interface().(json.Marshaler); ok {
+			return marshalJSON(value)
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		return normalizeJSON(value.Elem())
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.String:
+		if value.Type() == reflectT(json.Number("")) {
+			return json.Number(value.String()), nil
+		}
+
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(value.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return json.Number(strconv.FormatUint(value.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		f := value.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("unsupported value: %s", formatReflectValue(value))
+		}
+
+		return json.Number(strconv.FormatFloat(f, 'g', -1, value.Type().Bits())), nil
+	case reflect.Map:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		object := make(map[string]any, value.Len())
+
+		for _, vKey := range value.MapKeys() {
+			if vKey.Kind() == reflect.Interface {
+				vKey = vKey.Elem()
+			}
+
+			if vKey.Kind() != reflect.String {
+				return marshalJSON(value)
+			}
+
+			v, err := normalizeJSON(value.MapIndex(vKey))
+			if err != nil {
+				return nil, err
+			}
+
+			object[vKey.String()] = v
+		}
+
+		return object, nil
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil, nil
+		}
+
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return marshalJSON(value)
+		}
+
+		array := make([]any, value.Len())
+
+		for i := range array {
+			v, err := normalizeJSON(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+
+			array[i] = v
+		}
+
+		return array, nil
+	case reflect.Struct:
+		object := map[string]any{}
+		if err := normalizeJSONStruct(value, object); err != nil {
+			return nil, err
+		}
+
+		return object, nil
+	default:
+		return marshalJSON(value)
+	}
+}
+
+// normalizeJSONStruct walks the fields the way encoding/json does, so that matchers nested in the structs are kept.
+func normalizeJSONStruct(value reflect.Value, object map[string]any) error {
+	var embedded []reflect.Value
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		vField := value.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			if vField.Kind() == reflect.Pointer {
+				if vField.IsNil() {
+					continue
+				}
+
+				vField = vField.Elem()
+			}
+
+			if vField.Kind() == reflect.Struct {
+				embedded = append(embedded, vField)
+
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if jsonTagOption(options, "omitempty") && isEmptyJSONValue(vField) {
+			continue
+		}
+
+		v, err := normalizeJSON(vField)
+		if err != nil {
+			return err
+		}
+
+		if jsonTagOption(options, "string") {
+			switch value := v.(type) {
+			case json.Number, bool:
+				v = fmt.Sprint(value)
+			case string:
+				v = strconv.Quote(value)
+			}
+		}
+
+		object[name] = v
+	}
+
+	// Fields of embedded structs are promoted, unless the outer struct has a field with the same name.
+	for _, vEmbedded := range embedded {
+		promoted := map[string]any{}
+		if err := normalizeJSONStruct(vEmbedded, promoted); err != nil {
+			return err
+		}
+
+		for name, v := range promoted {
+			if _, exists := object[name]; !exists {
+				object[name] = v
+			}
+		}
+	}
+
+	return nil
+}
+
+func jsonTagOption(options, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isEmptyJSONValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
+func marshalJSON(value reflect.Value) (any, error) {
+	if !value.CanInterface() {
+		return nil, fmt.Errorf("unsupported value: %s", formatReflectValue(value))
+	}
+
+	text, err := json.Marshal(value.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJSON(text)
+}
+
+func plainJSON(value any) any {
+	switch value := value.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := value.Float64()
+
+		return f
+	case map[string]any:
+		object := make(map[string]any, len(value))
+		for key, v := range value {
+			object[key] = plainJSON(v)
+		}
+
+		return object
+	case []any:
+		array := make([]any, len(value))
+		for i, v := range value {
+			array[i] = plainJSON(v)
+		}
+
+		return array
+	default:
+		return value
+	}
+}
+
+func compareJSONNumbers(a, b json.Number) bool {
+	fA, _, errA := big.ParseFloat(string(a), 10, 256, big.ToNearestEven)
+	fB, _, errB := big.ParseFloat(string(b), 10, 256, big.ToNearestEven)
+
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	return fA.Cmp(fB) == 0
+}
+
+func formatJSON(value any) string {
+	switch value := value.(type) {
+	case Matcher:
+		return value.String()
+	case map[string]any:
+		items := make([]string, 0, len(value))
+		for _, key := range sortedJSONKeys(value) {
+			items = append(items, strconv.Quote(key)+":"+formatJSON(value[key]))
+		}
+
+		return "{" + strings.Join(items, ",") + "}"
+	case []any:
+		items := make([]string, len(value))
+		for i, v := range value {
+			items[i] = formatJSON(v)
+		}
+
+		return "[" + strings.Join(items, ",") + "]"
+	}
+
+	text, err := json.Marshal(value)
+	if err != nil {
+		return formatValue(value)
+	}
+
+	return string(text)
+}
+
+func compactJSON(text []byte) string {
+	buffer := bytes.Buffer{}
+	if err := json.Compact(&buffer, text); err != nil {
+		return string(text)
+	}
+
+	return buffer.String()
+}
+
+func jsonKeyPath(path, key string) string {
+	if jsonIdentifierRegexp.MatchString(key) {
+		return path + "." + key
+	}
+
+	return fmt.Sprintf("%s[%s]", path, strconv.Quote(key))
+}
+
+func sortedJSONKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
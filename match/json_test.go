@@ -0,0 +1,196 @@
+package match_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/internal"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestJSONSuite(t *testing.T) {
+	muchtest.Run(t, new(JSONSuite))
+}
+
+type JSONSuite struct {
+	pkgSuite
+}
+
+const jsonDocument = `{
+	"name": "Much",
+	"count": 2,
+	"ratio": 0.5,
+	"items": [{"id": 1, "tags": ["a"]}, {"id": 2, "tags": []}],
+	"weird key": null
+}`
+
+func (s *JSONSuite) TestJSON() {
+	s.S.Equal(`JSON({"a":1,"b":[true]})`, match.JSON(`{"a": 1, "b": [true]}`).String())
+	s.S.Equal(`JSON(map[string]any{"a":1})`, match.JSON(map[string]any{"a": 1}).String())
+
+	s.match(match.JSON(jsonDocument), jsonDocument)
+	s.match(match.JSON(jsonDocument), []byte(jsonDocument))
+	s.match(match.JSON(`{"b": 1.0, "a": [1e2]}`), `{"a":[100],"b":1}`)
+	s.match(match.JSON(`{"a": 1}`), json.RawMessage(`{"a":1}`))
+	s.match(match.JSON(`{"a": 1}`), map[string]any{"a": 1.0})
+	s.match(match.JSON(`"Much"`), `"Much"`)
+	s.match(match.JSON(`null`), `null`)
+
+	s.match(
+		match.JSON(map[string]any{
+			"name":      match.Prefix("Mu"),
+			"count":     uint8(2),
+			"ratio":     float32(0.5),
+			"items":     match.Len(2),
+			"weird key": nil,
+		}),
+		jsonDocument,
+	)
+
+	s.match(
+		match.JSON(map[string]any{
+			"name":  "Much",
+			"count": match.Between(1, 3),
+			"ratio": match.InDelta(0.4, 0.2),
+			"items": []any{
+				map[string]any{"id": 1, "tags": []string{"a"}},
+				map[string]any{"id": match.Greater(1), "tags": match.Empty()},
+			},
+			"weird key": match.Nil(),
+		}),
+		jsonDocument,
+	)
+
+	type item struct {
+		ID   int      `json:"id"`
+		Tags []string `json:"tags"`
+	}
+
+	s.match(match.JSON([]item{{ID: 1, Tags: []string{"a"}}, {ID: 2, Tags: []string{}}}), `[
+		{"tags": ["a"], "id": 1},
+		{"id": 2, "tags": []}
+	]`)
+	s.match(match.JSON(item{ID: 1, Tags: []string{match.Placeholder[string](match.Len(1))}}), `{"id":1,"tags":["a"]}`)
+}
+
+func (s *JSONSuite) TestJSON_Struct() {
+	type base struct {
+		Kind string `json:"kind"`
+	}
+
+	type item struct {
+		base
+
+		ID      any    `json:"id"`
+		Name    string `json:"name"`
+		Note    string `json:"note,omitempty"`
+		Count   int    `json:"count,string"`
+		Secret  string `json:"-"`
+		Default bool
+		hidden  int
+	}
+
+	expected := item{
+		base:  base{Kind: "much"},
+		ID:    match.Regexp(`^\d+$`),
+		Name:  "x",
+		Count: 2,
+	}
+
+	s.match(match.JSON(expected), `{"kind":"much","id":"123","name":"x","count":"2","Default":false}`)
+	s.match(match.JSON([]item{expected}), `[{"kind":"much","id":"1","name":"x","count":"2","Default":false}]`)
+
+	ok, desc := match.JSON(expected).Matches(`{"kind":"much","id":"abc","name":"x","count":"2","Default":false}`)
+	s.S.False(ok)
+	s.S.Contains(desc, "$.id: Regexp(`^\\d+$`): not matched: \"abc\"")
+
+	ok, _ = match.JSON(expected).Matches(`{"kind":"much","id":"1","name":"x","note":"","count":"2","Default":false}`)
+	s.S.False(ok)
+}
+
+func (s *JSONSuite) TestJSON_Diff() {
+	ok, desc := match.JSON(map[string]any{
+		"name":  "Wow",
+		"count": 3,
+		"items": []any{
+			map[string]any{"id": 1, "tags": []string{"a", "b"}},
+		},
+		"missing":   match.Any(),
+		"weird key": false,
+	}).Matches(jsonDocument)
+	s.S.False(ok)
+	s.S.Equal(
+		`JSON(map[string]any{"count":3, "items":[]any{map[string]any{"id":1, "tags":[]string{"a", "b"}}}, `+
+			`"missing":Any(), "name":"Wow", "weird key":false}): not equal: `+
+			`{"count":2,"items":[{"id":1,"tags":["a"]},{"id":2,"tags":[]}],"name":"Much","ratio":0.5,"weird key":null}`,
+		internal.TrimDiff(desc),
+	)
+	s.S.Equal(
+		internal.DiffPrefix+
+			"\t\t$.count: expected 3, got 2\n"+
+			"\t\t$.items[0].tags[1]: missing value: \"b\"\n"+
+			"\t\t$.items[1]: unexpected value: {\"id\":2,\"tags\":[]}\n"+
+			"\t\t$.missing: missing key with value: Any()\n"+
+			"\t\t$.name: expected \"Wow\", got \"Much\"\n"+
+			"\t\t$[\"weird key\"]: expected false, got null\n"+
+			"\t\t$.ratio: unexpected key with value: 0.5\n",
+		desc[len(internal.TrimDiff(desc)):],
+	)
+
+	ok, desc = match.JSON(`{"a": {"b": 1}}`).Matches(`{"a": {"b": "1"}}`)
+	s.S.False(ok)
+	s.S.Equal(`JSON({"a":{"b":1}}): not equal: {"a":{"b":"1"}}`+internal.DiffPrefix+
+		"\t\t$.a.b: expected 1, got \"1\"\n", desc)
+
+	ok, desc = match.JSON(`[1]`).Matches(`{"a": 1}`)
+	s.S.False(ok)
+	s.S.Equal(`JSON([1]): not equal: {"a":1}`+internal.DiffPrefix+"\t\t$: expected [1], got {\"a\":1}\n", desc)
+}
+
+func (s *JSONSuite) TestJSON_Invalid() {
+	s.match(match.JSON(`{"a": 1}`), `{"a":`, `JSON({"a":1}): invalid JSON: unexpected EOF: "{"a":"`)
+	s.match(match.JSON(`{"a": 1}`), `{} {}`,
+		`JSON({"a":1}): invalid JSON: unexpected data after top-level value: "{} {}"`)
+	s.match(match.JSON(`{"a":`), `{}`, `Invalid JSON(): unexpected EOF Parameters: [{"a":]`)
+	s.match(match.JSON(complex(1, 2)), `{}`,
+		"Invalid JSON(): json: unsupported type: complex128 Parameters: [(1+2i)]")
+}
+
+func (s *JSONSuite) TestJSONPath() {
+	s.S.Equal(`JSONPath("$.items[*].id", Len(2))`, match.JSONPath("$.items[*].id", match.Len(2)).String())
+	s.S.Equal(`JSONPath("$.name", JSON("Much"))`, match.JSONPath("$.name", "Much").String())
+
+	s.match(match.JSONPath("$", match.Len(5)), jsonDocument)
+	s.match(match.JSONPath("$.name", "Much"), jsonDocument)
+	s.match(match.JSONPath("$.count", 2.0), jsonDocument)
+	s.match(match.JSONPath("$.count", match.Between(1, 3)), jsonDocument)
+	s.match(match.JSONPath("$.items[1].id", 2), jsonDocument)
+	s.match(match.JSONPath("$.items[-1].id", 2), []byte(jsonDocument))
+	s.match(match.JSONPath(`$['items'][0]["tags"]`, []string{"a"}), jsonDocument)
+	s.match(match.JSONPath(`$["weird key"]`, nil), jsonDocument)
+	s.match(match.JSONPath("$.items[*].id", []int{1, 2}), jsonDocument)
+	s.match(match.JSONPath("$.items[*].id", match.Contains(2)), jsonDocument)
+	s.match(match.JSONPath("$.items.*.tags[*]", []string{"a"}), jsonDocument)
+	s.match(match.JSONPath("$.items[*].missing", match.Empty()), jsonDocument)
+	s.match(match.JSONPath("$.name", match.Regexp("^M")), jsonDocument)
+
+	s.match(match.JSONPath("$.items[2].id", 3), jsonDocument,
+		`JSONPath("$.items[2].id", JSON(3)): path not found: $.items[2]: `+
+			`{"count":2,"items":[{"id":1,"tags":["a"]},{"id":2,"tags":[]}],"name":"Much","ratio":0.5,"weird key":null}`)
+	s.match(match.JSONPath("$.count", match.Greater(2)), jsonDocument,
+		`JSONPath("$.count", Greater(2)): not matched: Greater(2): not greater: 2`)
+
+	ok, desc := match.JSONPath("$.items[*].id", []int{1, 3}).Matches(jsonDocument)
+	s.S.False(ok)
+	s.S.Equal(`JSONPath("$.items[*].id", JSON([]int{1, 3})): not matched: JSON([]int{1, 3}): not equal: [1,2]`+
+		internal.DiffPrefix+"\t\t$.items[*].id[1]: expected 3, got 2\n", desc)
+
+	s.match(match.JSONPath("items", 1), jsonDocument, "Invalid JSONPath(): path must start with $ Parameters: [items]")
+	s.match(match.JSONPath("$.items[", 1), jsonDocument,
+		"Invalid JSONPath(): unclosed bracket at offset 7 Parameters: [$.items[]")
+	s.match(match.JSONPath("$.items[x]", 1), jsonDocument,
+		"Invalid JSONPath(): invalid index x at offset 7 Parameters: [$.items[x]]")
+	s.match(match.JSONPath("$..items", 1), jsonDocument,
+		"Invalid JSONPath(): empty key at offset 1 Parameters: [$..items]")
+}
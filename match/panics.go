@@ -0,0 +1,85 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+)
+
+func Panics(expected ...any) Matcher {
+	if len(expected) > 1 {
+		return matcherErr("Invalid Panics(): expected must be a single value or matcher, or nothing.", expected)
+	}
+
+	m := panicsMatcher{}
+	if len(expected) == 1 {
+		m.expected = ToMatcher(expected[0])
+	}
+
+	return m
+}
+
+func NotPanics() Matcher {
+	return panicsMatcher{not: true}
+}
+
+type panicsMatcher struct {
+	expected Matcher
+	not      bool
+}
+
+func (m panicsMatcher) Matches(actual any) (ok bool, desc string) {
+	vActual := reflectV(actual)
+	if vActual.Kind() != reflect.Func || vActual.Type().NumIn() != 0 || vActual.IsNil() {
+		return false, fmt.Sprintf("%s: actual must be func(): %s", m.String(), formatValue(actual))
+	}
+
+	panicked, value := m.call(vActual)
+
+	if m.not {
+		if panicked {
+			return false, fmt.Sprintf("%s: panicked: %s", m.String(), formatValue(value))
+		}
+
+		return true, ""
+	}
+
+	if !panicked {
+		return false, m.String() + ": didn't panic"
+	}
+
+	if m.expected == nil {
+		return true, ""
+	}
+
+	if ok, _ = m.expected.Matches(value); !ok {
+		return false, fmt.Sprintf("%s: panic value not matched: %s", m.String(), formatValue(value))
+	}
+
+	return true, ""
+}
+
+func (m panicsMatcher) call(fn reflect.Value) (panicked bool, value any) {
+	panicked = true
+
+	defer func() {
+		if panicked {
+			value = recover()
+		}
+	}()
+
+	fn.Call(nil)
+
+	return false, nil
+}
+
+func (m panicsMatcher) String() string {
+	if m.not {
+		return "NotPanics()"
+	}
+
+	if m.expected == nil {
+		return "Panics()"
+	}
+
+	return fmt.Sprintf("Panics(%s)", m.expected.String())
+}
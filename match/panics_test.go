@@ -0,0 +1,38 @@
+package match_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestPanicsSuite(t *testing.T) {
+	muchtest.Run(t, new(PanicsSuite))
+}
+
+type PanicsSuite struct {
+	pkgSuite
+}
+
+func (s *PanicsSuite) TestPanics() {
+	s.S.Equal("Panics()", match.Panics().String())
+	s.S.Equal(`Panics(Equal("Much"))`, match.Panics("Much").String())
+
+	s.match(match.Panics(), func() { panic("Much") })
+	s.match(match.Panics("Much"), func() { panic("Much") })
+	s.match(match.Panics(match.ErrorMsg("Much")), func() { panic(errors.New("Much")) })
+	s.match(match.Panics(), func() {}, "Panics(): didn't panic")
+	s.match(match.Panics("Much"), func() { panic("Such") }, `Panics(Equal("Much")): panic value not matched: "Such"`)
+	s.match(match.Panics(), "Much", `Panics(): actual must be func(): "Much"`)
+	s.match(match.Panics(1, 2), func() {}, "Invalid Panics(): expected must be a single value or matcher, or "+
+		"nothing. Parameters: [[1 2]]")
+}
+
+func (s *PanicsSuite) TestNotPanics() {
+	s.S.Equal("NotPanics()", match.NotPanics().String())
+
+	s.match(match.NotPanics(), func() {})
+	s.match(match.NotPanics(), func() { panic("Much") }, `NotPanics(): panicked: "Much"`)
+}
@@ -0,0 +1,151 @@
+package match
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var placeholders = struct {
+	sync.RWMutex
+	matchers map[placeholderKey]Matcher
+	values   []any
+	lastID   uint64
+}{matchers: map[placeholderKey]Matcher{}}
+
+const placeholderKinds = "supported are pointers and slices of non-zero-sized elements, maps, channels, strings, " +
+	"floats, time.Time and the types the matcher is assignable to"
+
+type placeholderKey struct {
+	kind reflect.Kind
+	ptr  uintptr
+	str  string
+}
+
+// Placeholder returns a value of type T that stands for the matcher inside an expected value; Equal() and the other
+// matchers that compare values use the matcher in its place. It's meant for struct fields, elements etc. whose value
+// isn't known up front:
+//
+//	match.Equal(User{ID: match.Placeholder[string](match.Prefix("user-")), Name: "doge"})
+//
+// Types that the matcher is assignable to (e.g. any) hold the matcher itself. Others hold a unique sentinel value,
+// which only some kinds can represent: pointers and slices (of non-zero-sized elements), maps, channels, strings,
+// floats and time.Time. Placeholder panics for the rest, such as ints and bools, as there's no value to spare.
+func Placeholder[T any](matcher any) T {
+	var placeholder T
+
+	m := ToMatcher(matcher)
+	tPlaceholder := reflectT(&placeholder).Elem()
+
+	if tMatcher := reflectT(m); tMatcher.AssignableTo(tPlaceholder) {
+		reflectV(&placeholder).Elem().Set(reflectV(m))
+
+		return placeholder
+	}
+
+	placeholders.Lock()
+	defer placeholders.Unlock()
+
+	placeholders.lastID++
+	id := placeholders.lastID
+
+	var vPlaceholder reflect.Value
+
+	switch tPlaceholder.Kind() {
+	case reflect.Pointer, reflect.Slice:
+		if tPlaceholder.Elem().Size() == 0 {
+			panic(fmt.Sprintf("Invalid Placeholder(): unsupported type %s (zero-sized element); %s",
+				tPlaceholder, placeholderKinds))
+		}
+	}
+
+	switch tPlaceholder.Kind() {
+	case reflect.Pointer:
+		vPlaceholder = reflect.New(tPlaceholder.Elem())
+	case reflect.Map:
+		vPlaceholder = reflect.MakeMap(tPlaceholder)
+	case reflect.Slice:
+		vPlaceholder = reflect.MakeSlice(tPlaceholder, 0, 1)
+	case reflect.Chan:
+		vPlaceholder = reflect.MakeChan(tPlaceholder, 0)
+	case reflect.String:
+		vPlaceholder = reflect.New(tPlaceholder).Elem()
+		vPlaceholder.SetString(fmt.Sprintf("\x00match.Placeholder(%d)\x00", id))
+	case reflect.Float32, reflect.Float64:
+		vPlaceholder = reflect.New(tPlaceholder).Elem()
+		vPlaceholder.SetFloat(math.Float64frombits(0x7ff8000000000000 | id<<29))
+	default:
+		if tPlaceholder != reflectT(time.Time{}) {
+			panic(fmt.Sprintf("Invalid Placeholder(): unsupported type %s; %s", tPlaceholder, placeholderKinds))
+		}
+
+		location := time.FixedZone(fmt.Sprintf("match.Placeholder(%d)", id), 0)
+		vPlaceholder = reflectV(time.Time{}.In(location))
+	}
+
+	key, _ := getPlaceholderKey(vPlaceholder)
+
+	placeholders.matchers[key] = m
+	placeholders.values = append(placeholders.values, vPlaceholder.Interface())
+
+	return vPlaceholder.Interface().(T)
+}
+
+func lookupPlaceholder(value reflect.Value) (Matcher, bool) {
+	key, ok := getPlaceholderKey(value)
+	if !ok {
+		return nil, false
+	}
+
+	placeholders.RLock()
+	defer placeholders.RUnlock()
+
+	matcher, ok := placeholders.matchers[key]
+
+	return matcher, ok
+}
+
+func getPlaceholderKey(value reflect.Value) (placeholderKey, bool) {
+	switch kind := value.Kind(); kind {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan:
+		if value.IsNil() {
+			return placeholderKey{}, false
+		}
+
+		return placeholderKey{kind: kind, ptr: value.Pointer()}, true
+	case reflect.String:
+		return placeholderKey{kind: kind, str: value.String()}, true
+	case reflect.Float32, reflect.Float64:
+		if f := value.Float(); !math.IsNaN(f) {
+			return placeholderKey{}, false
+		}
+
+		return placeholderKey{kind: reflect.Float64, ptr: uintptr(math.Float64bits(value.Float()))}, true
+	case reflect.Struct:
+		if !value.CanInterface() {
+			return placeholderKey{}, false
+		}
+
+		if t, ok := value.Interface().(time.Time); ok {
+			return placeholderKey{kind: kind, str: t.Location().String()}, true
+		}
+	}
+
+	return placeholderKey{}, false
+}
+
+func asMatcher(value reflect.Value) (Matcher, bool) {
+	if !value.IsValid() {
+		return nil, false
+	}
+
+	if value.CanInterface() {
+		if matcher, ok := value.Interface().(Matcher); ok {
+			return matcher, true
+		}
+	}
+
+	return lookupPlaceholder(value)
+}
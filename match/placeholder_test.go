@@ -0,0 +1,106 @@
+package match_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/internal"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestPlaceholderSuite(t *testing.T) {
+	muchtest.Run(t, new(PlaceholderSuite))
+}
+
+type PlaceholderSuite struct {
+	pkgSuite
+}
+
+type placeholderT struct {
+	ID        string
+	Score     float64
+	Ratio     float32
+	CreatedAt time.Time
+	Parent    *placeholderT
+	Tags      []string
+	Attrs     map[string]int
+	Any       any
+}
+
+func (s *PlaceholderSuite) TestPlaceholder() {
+	createdAt := time.Date(2022, 8, 26, 13, 14, 58, 0, time.UTC)
+	actual := placeholderT{
+		ID:        "a7e5",
+		Score:     12.5,
+		Ratio:     0.5,
+		CreatedAt: createdAt,
+		Parent:    &placeholderT{ID: "b"},
+		Tags:      []string{"much", "test"},
+		Attrs:     map[string]int{"much": 1},
+		Any:       123,
+	}
+
+	expected := placeholderT{
+		ID:        match.Placeholder[string](match.Regexp(`^[0-9a-f]+$`)),
+		Score:     match.Placeholder[float64](match.Between(12, 13)),
+		Ratio:     match.Placeholder[float32](match.Positive()),
+		CreatedAt: match.Placeholder[time.Time](match.Greater(createdAt.Add(-time.Minute))),
+		Parent:    match.Placeholder[*placeholderT](match.Not(match.Nil())),
+		Tags:      match.Placeholder[[]string](match.Contains("much")),
+		Attrs:     match.Placeholder[map[string]int](match.ContainsKey("much")),
+		Any:       match.Placeholder[any](match.Positive()),
+	}
+
+	s.match(match.Equal(expected), actual)
+	s.S.Equal(
+		"Equal(match_test.placeholderT{Any:Positive(), Attrs:ContainsKey(\"much\"), "+
+			"CreatedAt:Greater(2022-08-26 13:13:58), ID:Regexp(`^[0-9a-f]+$`), Parent:Not(Nil()), Ratio:Positive(), "+
+			"Score:Between(12, 13), Tags:Contains(\"much\")})",
+		match.Equal(expected).String(),
+	)
+
+	actual.ID = "much"
+	actual.Score = 11
+	actual.Tags = nil
+
+	ok, desc := match.Equal(expected).Matches(actual)
+	s.S.False(ok)
+	s.S.Equal(
+		internal.DiffPrefix+
+			"\t\t.ID: Regexp(`^[0-9a-f]+$`): not matched: \"much\"\n"+
+			"\t\t.Score: Between(12, 13): outside range: 11\n"+
+			"\t\t.Tags: Contains(\"much\"): no such value in: []string{}\n",
+		desc[len(internal.TrimDiff(desc)):],
+	)
+}
+
+func (s *PlaceholderSuite) TestPlaceholder_NilSiblings() {
+	expected := placeholderT{ID: match.Placeholder[string](match.Prefix("a7")), Any: match.Any()}
+
+	s.match(match.Equal(expected), placeholderT{ID: "a7e5", Any: 5})
+	s.match(match.Equal(expected), &placeholderT{ID: "a7e5"})
+
+	ok, desc := match.Equal(expected).Matches(placeholderT{ID: "a7e5", Parent: &placeholderT{}})
+	s.S.False(ok)
+	s.S.Equal(
+		internal.DiffPrefix+"\t\t.Parent: expected (*match_test.placeholderT)(nil), got *match_test.placeholderT{}\n",
+		desc[len(internal.TrimDiff(desc)):],
+	)
+}
+
+func (s *PlaceholderSuite) TestPlaceholder_Unsupported() {
+	const supported = "supported are pointers and slices of non-zero-sized elements, maps, channels, strings, " +
+		"floats, time.Time and the types the matcher is assignable to"
+
+	s.S.PanicsWithValue("Invalid Placeholder(): unsupported type int; "+supported, func() {
+		match.Placeholder[int](match.Any())
+	})
+	s.S.PanicsWithValue("Invalid Placeholder(): unsupported type bool; "+supported, func() {
+		match.Placeholder[bool](match.Any())
+	})
+	s.S.PanicsWithValue(
+		"Invalid Placeholder(): unsupported type *struct {} (zero-sized element); "+supported,
+		func() { match.Placeholder[*struct{}](match.Any()) },
+	)
+}
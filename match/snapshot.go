@@ -0,0 +1,228 @@
+package match
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/grongor/go-muchtest/internal"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+func Snapshot(path string) Matcher {
+	return snapshotMatcher{path: path}
+}
+
+type snapshotMatcher struct {
+	path string
+}
+
+func (m snapshotMatcher) Matches(actual any) (ok bool, desc string) {
+	internal.UseSnapshot(m.path)
+
+	content := serializeSnapshot(actual)
+
+	if internal.UpdateSnapshots() {
+		return m.write(content)
+	}
+
+	snapshot, err := os.ReadFile(m.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, m.diff(
+			": snapshot doesn't exist (run with -muchtest.update to create it)",
+			"/dev/null",
+			nil,
+			snapshotLines(content),
+		)
+	}
+
+	if err != nil {
+		return false, fmt.Sprintf("%s: can't read snapshot: %s", m.String(), err.Error())
+	}
+
+	if string(snapshot) == content {
+		return true, ""
+	}
+
+	return false, m.diff(": doesn't match the snapshot", "Snapshot", snapshotLines(string(snapshot)), snapshotLines(content))
+}
+
+func (m snapshotMatcher) diff(message, fromFile string, snapshot, actual []string) string {
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        snapshot,
+		B:        actual,
+		FromFile: fromFile,
+		ToFile:   "Actual",
+		Context:  2,
+	})
+
+	builder := strings.Builder{}
+	builder.WriteString(m.String())
+	builder.WriteString(message)
+	builder.WriteString(internal.DiffPrefix)
+
+	for _, line := range strings.SplitAfter(strings.TrimSuffix(diff, "\n"), "\n") {
+		builder.WriteString("\t\t")
+		builder.WriteString(strings.TrimSuffix(line, "\n"))
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
+
+func (m snapshotMatcher) write(content string) (ok bool, desc string) {
+	err := os.MkdirAll(filepath.Dir(m.path), 0o755)
+	if err == nil {
+		err = os.WriteFile(m.path, []byte(content), 0o644)
+	}
+
+	if err != nil {
+		return false, fmt.Sprintf("%s: can't write snapshot: %s", m.String(), err.Error())
+	}
+
+	return true, ""
+}
+
+func (m snapshotMatcher) String() string {
+	return fmt.Sprintf("Snapshot(%s)", strconv.Quote(filepath.ToSlash(m.path)))
+}
+
+func serializeSnapshot(actual any) string {
+	switch actual := actual.(type) {
+	case string:
+		return actual
+	case []byte:
+		return string(actual)
+	default:
+		vActual := reflectV(actual)
+		for vActual.Kind() == reflect.Pointer && !vActual.IsNil() {
+			vActual = vActual.Elem()
+		}
+
+		builder := &strings.Builder{}
+		formatSnapshotValue(builder, vActual, "")
+		builder.WriteByte('\n')
+
+		return builder.String()
+	}
+}
+
+const snapshotLineLength = 80
+
+// formatSnapshotValue formats values like formatReflectValue does, but puts the elements of the values which don't
+// fit on a single line on separate lines, so that the snapshot diffs are readable.
+func formatSnapshotValue(builder *strings.Builder, value reflect.Value, indent string) {
+	if line := formatReflectValue(value); len(indent)+len(line) <= snapshotLineLength || !value.CanInterface() {
+		builder.WriteString(line)
+
+		return
+	}
+
+	if _, ok := formatString(value.Interface()); ok {
+		builder.WriteString(formatReflectValue(value))
+
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			builder.WriteString("nil")
+
+			return
+		}
+
+		if value.Kind() == reflect.Pointer {
+			builder.WriteByte('*')
+		}
+
+		formatSnapshotValue(builder, value.Elem(), indent)
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			builder.WriteString(formatReflectValue(value))
+
+			return
+		}
+
+		builder.WriteString("[]")
+		builder.WriteString(stringAny(value.Type().Elem().String()))
+		builder.WriteString("{\n")
+
+		for i := 0; i < value.Len(); i++ {
+			builder.WriteString(indent + "\t")
+			formatSnapshotValue(builder, value.Index(i), indent+"\t")
+			builder.WriteString(",\n")
+		}
+
+		builder.WriteString(indent + "}")
+	case reflect.Map:
+		builder.WriteString("map[")
+		builder.WriteString(stringAny(value.Type().Key().String()))
+		builder.WriteByte(']')
+		builder.WriteString(stringAny(value.Type().Elem().String()))
+		builder.WriteString("{\n")
+
+		type keyAndString struct {
+			vKey   reflect.Value
+			string string
+		}
+
+		keyAndStrings := make([]keyAndString, 0, value.Len())
+
+		for _, vKey := range value.MapKeys() {
+			keyAndStrings = append(keyAndStrings, keyAndString{vKey: vKey, string: formatReflectValue(vKey)})
+		}
+
+		sort.SliceStable(keyAndStrings, func(i, j int) bool {
+			return keyAndStrings[i].string < keyAndStrings[j].string
+		})
+
+		for _, ks := range keyAndStrings {
+			builder.WriteString(indent + "\t" + ks.string + ": ")
+			formatSnapshotValue(builder, value.MapIndex(ks.vKey), indent+"\t")
+			builder.WriteString(",\n")
+		}
+
+		builder.WriteString(indent + "}")
+	case reflect.Struct:
+		builder.WriteString(value.Type().String())
+		builder.WriteString("{\n")
+
+		names := make([]string, 0, value.NumField())
+		fields := make(map[string]reflect.Value, value.NumField())
+
+		for i := 0; i < value.NumField(); i++ {
+			if field := value.Field(i); field.CanInterface() && !field.IsZero() {
+				names = append(names, value.Type().Field(i).Name)
+				fields[value.Type().Field(i).Name] = field
+			}
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			builder.WriteString(indent + "\t" + name + ": ")
+			formatSnapshotValue(builder, fields[name], indent+"\t")
+			builder.WriteString(",\n")
+		}
+
+		builder.WriteString(indent + "}")
+	default:
+		builder.WriteString(formatReflectValue(value))
+	}
+}
+
+func snapshotLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
@@ -0,0 +1,161 @@
+package match_test
+
+import (
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/internal"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestSnapshotSuite(t *testing.T) {
+	muchtest.Run(t, new(SnapshotSuite))
+}
+
+type SnapshotSuite struct {
+	pkgSuite
+}
+
+func (s *SnapshotSuite) TestSnapshot() {
+	path := filepath.Join(s.T().TempDir(), "nested", "snapshot")
+
+	s.S.Equal(`Snapshot("lorem/ipsum")`, match.Snapshot("lorem/ipsum").String())
+
+	ok, desc := match.Snapshot(path).Matches("much\nwow\nsuch\ntest\n")
+	s.S.False(ok)
+	s.S.Equal(
+		`Snapshot("`+filepath.ToSlash(path)+`"): snapshot doesn't exist (run with -muchtest.update to create it)`+
+			internal.DiffPrefix+
+			"\t\t--- /dev/null\n"+
+			"\t\t+++ Actual\n"+
+			"\t\t@@ -0,0 +1,4 @@\n"+
+			"\t\t+much\n"+
+			"\t\t+wow\n"+
+			"\t\t+such\n"+
+			"\t\t+test\n",
+		desc,
+	)
+	_, err := os.Stat(path)
+	s.S.ErrorIs(err, fs.ErrNotExist)
+	s.S.True(internal.SnapshotUsed(path))
+
+	s.update(func() {
+		s.match(match.Snapshot(path), "much\nwow\nsuch\ntest\n")
+	})
+	s.snapshotContent(path, "much\nwow\nsuch\ntest\n")
+
+	s.match(match.Snapshot(path), []byte("much\nwow\nsuch\ntest\n"))
+
+	ok, desc = match.Snapshot(path).Matches("much\nwow\nvery\ntest\n")
+	s.S.False(ok)
+	s.S.Equal(
+		`Snapshot("`+filepath.ToSlash(path)+`"): doesn't match the snapshot`+internal.DiffPrefix+
+			"\t\t--- Snapshot\n"+
+			"\t\t+++ Actual\n"+
+			"\t\t@@ -1,4 +1,4 @@\n"+
+			"\t\t much\n"+
+			"\t\t wow\n"+
+			"\t\t-such\n"+
+			"\t\t+very\n"+
+			"\t\t test\n",
+		desc,
+	)
+	s.snapshotContent(path, "much\nwow\nsuch\ntest\n")
+}
+
+func (s *SnapshotSuite) TestSnapshot_Values() {
+	dir := s.T().TempDir()
+
+	s.update(func() {
+		s.match(match.Snapshot(filepath.Join(dir, "map")), map[string]any{"b": []int{1, 2}, "a": "much"})
+		s.match(match.Snapshot(filepath.Join(dir, "pointer")), &stringer{Text: "Much"})
+		s.match(match.Snapshot(filepath.Join(dir, "nil")), nil)
+	})
+
+	s.snapshotContent(filepath.Join(dir, "map"), `map[string]any{"a":"much", "b":[]int{1, 2}}`+"\n")
+	s.snapshotContent(filepath.Join(dir, "pointer"), "match_test.stringer(Much)\n")
+	s.snapshotContent(filepath.Join(dir, "nil"), "nil\n")
+}
+
+func (s *SnapshotSuite) TestSnapshot_MultiLine() {
+	type item struct {
+		Name  string
+		Tags  []string
+		Attrs map[string]any
+		Next  *item
+	}
+
+	path := filepath.Join(s.T().TempDir(), "struct")
+	value := []item{
+		{Name: "much", Tags: []string{"such", "wow"}},
+		{
+			Name:  "very long name of the second item, which makes it not fit on a single line",
+			Attrs: map[string]any{"b": 1, "a": []int{1, 2}},
+			Next:  &item{Name: "next", Tags: []string{"amaze"}},
+		},
+	}
+
+	s.update(func() {
+		s.match(match.Snapshot(path), value)
+	})
+
+	s.snapshotContent(path, `[]match_test.item{
+	match_test.item{Name:"much", Tags:[]string{"such", "wow"}},
+	match_test.item{
+		Attrs: map[string]any{"a":[]int{1, 2}, "b":1},
+		Name: "very long name of the second item, which makes it not fit on a single line",
+		Next: *match_test.item{Name:"next", Tags:[]string{"amaze"}},
+	},
+}
+`)
+
+	value[1].Next.Tags = nil
+	value[1].Attrs["b"] = 2
+
+	ok, desc := match.Snapshot(path).Matches(value)
+	s.S.False(ok)
+	s.S.Contains(desc, "\t\t-\t\tAttrs: map[string]any{\"a\":[]int{1, 2}, \"b\":1},\n"+
+		"\t\t+\t\tAttrs: map[string]any{\"a\":[]int{1, 2}, \"b\":2},\n")
+	s.S.Contains(desc, "\t\t+\t\tNext: *match_test.item{Name:\"next\"},\n")
+}
+
+func (s *SnapshotSuite) TestSnapshot_Update() {
+	path := filepath.Join(s.T().TempDir(), "snapshot")
+
+	s.S.NoError(os.WriteFile(path, []byte("much"), 0o644))
+	s.match(match.Snapshot(path), "wow", `Snapshot("`+filepath.ToSlash(path)+`"): doesn't match the snapshot`)
+
+	s.update(func() {
+		s.match(match.Snapshot(path), "wow")
+	})
+	s.snapshotContent(path, "wow")
+}
+
+func (s *SnapshotSuite) TestSnapshot_Unreadable() {
+	path := s.T().TempDir()
+
+	s.matchFn(match.Snapshot(path), "much", func(desc string) {
+		s.S.Regexp(`^Snapshot\(".+"\): can't read snapshot: read .+: is a directory$`, desc)
+	})
+}
+
+func (s *SnapshotSuite) update(fn func()) {
+	s.S.NoError(flag.Set("muchtest.update", "true"))
+	defer func() {
+		s.S.NoError(flag.Set("muchtest.update", "false"))
+	}()
+
+	fn()
+}
+
+func (s *SnapshotSuite) snapshotContent(path, expected string) {
+	s.T().Helper()
+
+	content, err := os.ReadFile(path)
+	s.S.NoError(err)
+	s.S.Equal(expected, string(content))
+}
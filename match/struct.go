@@ -0,0 +1,203 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+type StructMatcher interface {
+	Matcher
+	Unexported() Matcher
+}
+
+func Struct(nameAndValues ...any) StructMatcher {
+	return newStructMatcher("Struct", nameAndValues)
+}
+
+func StructExact(nameAndValues ...any) StructMatcher {
+	return newStructMatcher("StructExact", nameAndValues)
+}
+
+func newStructMatcher(name string, nameAndValues []any) StructMatcher {
+	if len(nameAndValues)%2 != 0 {
+		return matcherErr(
+			"Invalid " + name + `(): nameAndValues must be pairs; eg.: "Name", "lorem", "Address.City", "Prague"`,
+		)
+	}
+
+	m := structMatcher{name: name, nameAndValues: nameAndValues, exact: name == "StructExact"}
+
+	for i := 0; i < len(nameAndValues); i += 2 {
+		path, ok := nameAndValues[i].(string)
+		if !ok || path == "" {
+			return matcherErr(fmt.Sprintf("Invalid %s(): field name must be a non-empty string.", name), nameAndValues[i])
+		}
+
+		m.paths = append(m.paths, path)
+		m.matchers = append(m.matchers, ToMatcher(nameAndValues[i+1]))
+	}
+
+	return m
+}
+
+type structMatcher struct {
+	name          string
+	nameAndValues []any
+	paths         []string
+	matchers      []Matcher
+	exact         bool
+	unexported    bool
+}
+
+func (m structMatcher) Matches(actual any) (ok bool, desc string) {
+	vActual := indirect(reflectV(actual))
+	for vActual.Kind() == reflect.Pointer && !vActual.IsNil() {
+		vActual = vActual.Elem()
+	}
+
+	if actual == nil {
+		return false, fmt.Sprintf("%s: not a struct: %s", m.String(), formatValue(actual))
+	}
+
+	if vActual.Kind() != reflect.Struct {
+		return false, fmt.Sprintf("%s: not a struct: %T(%s)", m.String(), actual, formatValue(actual))
+	}
+
+	vActual = addressable(vActual)
+	diff := &equalDiff{}
+
+	for i, path := range m.paths {
+		vField, err := m.field(vActual, path)
+		if err != "" {
+			diff.add(path, "%s", err)
+
+			continue
+		}
+
+		var value any = vField
+		if vField.CanInterface() {
+			value = vField.Interface()
+		}
+
+		if ok, desc := m.matchers[i].Matches(value); !ok {
+			diff.add(path, "%s", desc)
+		}
+	}
+
+	if m.exact {
+		m.checkUnmentioned(diff, vActual, "")
+	}
+
+	if len(diff.paths) == 0 {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("%s: not matched: %s%s", m.String(), formatValue(actual), diff.String())
+}
+
+func (m structMatcher) field(vStruct reflect.Value, path string) (reflect.Value, string) {
+	names := strings.Split(path, ".")
+
+	for i, name := range names {
+		for vStruct.Kind() == reflect.Pointer || vStruct.Kind() == reflect.Interface {
+			if vStruct.IsNil() {
+				return reflect.Value{}, fmt.Sprintf("nil at %s", strings.Join(names[:i], "."))
+			}
+
+			vStruct = vStruct.Elem()
+		}
+
+		if vStruct.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Sprintf("not a struct at %s: %s", strings.Join(names[:i], "."),
+				formatReflectValue(vStruct))
+		}
+
+		field, ok := vStruct.Type().FieldByName(name)
+		if !ok || !field.IsExported() && !m.unexported {
+			return reflect.Value{}, fmt.Sprintf("no such field in: %s", vStruct.Type())
+		}
+
+		vStruct = m.accessible(vStruct.FieldByIndex(field.Index))
+	}
+
+	return vStruct, ""
+}
+
+func (m structMatcher) checkUnmentioned(diff *equalDiff, vStruct reflect.Value, prefix string) {
+	for vStruct.Kind() == reflect.Pointer && !vStruct.IsNil() {
+		vStruct = vStruct.Elem()
+	}
+
+	if vStruct.Kind() != reflect.Struct {
+		return
+	}
+
+	tStruct := vStruct.Type()
+
+Fields:
+	for i := 0; i < vStruct.NumField(); i++ {
+		field := tStruct.Field(i)
+		if !field.IsExported() && !m.unexported {
+			continue
+		}
+
+		path := prefix + field.Name
+		vField := m.accessible(vStruct.Field(i))
+		nested := false
+
+		for _, p := range m.paths {
+			if p == path {
+				continue Fields
+			}
+
+			nested = nested || strings.HasPrefix(p, path+".")
+		}
+
+		if nested {
+			m.checkUnmentioned(diff, vField, path+".")
+
+			continue
+		}
+
+		if !vField.IsZero() {
+			diff.add(path, "unexpected non-zero value: %s", formatReflectValue(vField))
+		}
+	}
+}
+
+func (m structMatcher) accessible(vField reflect.Value) reflect.Value {
+	if vField.CanInterface() || !vField.CanAddr() {
+		return vField
+	}
+
+	return reflect.NewAt(vField.Type(), unsafe.Pointer(vField.UnsafeAddr())).Elem()
+}
+
+func (m structMatcher) Unexported() Matcher {
+	m.unexported = true
+
+	return m
+}
+
+func (m structMatcher) String() string {
+	args := strings.TrimSuffix(strings.TrimPrefix(formatValue(m.nameAndValues), "[]any{"), "}")
+
+	if m.unexported {
+		return fmt.Sprintf("%s(%s).Unexported()", m.name, args)
+	}
+
+	return fmt.Sprintf("%s(%s)", m.name, args)
+}
+
+func addressable(value reflect.Value) reflect.Value {
+	if value.CanAddr() {
+		return value
+	}
+
+	vCopy := reflect.New(value.Type()).Elem()
+	vCopy.Set(value)
+
+	return vCopy
+}
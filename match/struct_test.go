@@ -0,0 +1,106 @@
+package match_test
+
+import (
+	"testing"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/internal"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestStructSuite(t *testing.T) {
+	muchtest.Run(t, new(StructSuite))
+}
+
+type StructSuite struct {
+	pkgSuite
+}
+
+type structAddress struct {
+	City   string
+	Street string
+}
+
+type structPerson struct {
+	Name    string
+	Age     int
+	Address *structAddress
+	Tags    []string
+	secret  string
+}
+
+func (s *StructSuite) person() structPerson {
+	return structPerson{
+		Name:    "Much",
+		Age:     42,
+		Address: &structAddress{City: "Prague", Street: "Main"},
+		secret:  "hidden",
+	}
+}
+
+func (s *StructSuite) TestStruct() {
+	s.S.Equal(`Struct("Name", "Much", "Age", Between(18, 99))`,
+		match.Struct("Name", "Much", "Age", match.Between(18, 99)).String())
+	s.S.Equal(`Struct("secret", "hidden").Unexported()`, match.Struct("secret", "hidden").Unexported().String())
+
+	person := s.person()
+
+	s.match(match.Struct(), person)
+	s.match(match.Struct("Name", "Much"), person)
+	s.match(match.Struct("Name", "Much", "Address.City", "Prague"), person)
+	s.match(match.Struct("Age", match.Between(18, 99), "Address.City", match.Prefix("Pra")), &person)
+	s.match(match.Struct("Tags", match.Nil()), person)
+	s.match(match.Struct("secret", "hidden").Unexported(), person)
+	s.match(match.Struct("secret", "hidden").Unexported(), &person)
+
+	ok, desc := match.Struct("Name", "Wow", "Address.City", "Brno").Matches(person)
+	s.S.False(ok)
+	s.S.Regexp(`^Struct\("Name", "Wow", "Address.City", "Brno"\): not matched: match_test.structPerson{`, desc)
+	s.S.Contains(desc, internal.DiffPrefix+
+		"\t\tName: Equal(\"Wow\"): not equal: \"Much\"\n"+
+		"\t\tAddress.City: Equal(\"Brno\"): not equal: \"Prague\"\n",
+	)
+}
+
+func (s *StructSuite) TestStruct_InvalidPaths() {
+	person := s.person()
+	person.Address = nil
+
+	ok, desc := match.Struct("Address.City", "Prague", "Nope", 1, "Name.Len", 4, "secret", "hidden").Matches(person)
+	s.S.False(ok)
+	s.S.Contains(desc, internal.DiffPrefix+
+		"\t\tAddress.City: nil at Address\n"+
+		"\t\tNope: no such field in: match_test.structPerson\n"+
+		"\t\tName.Len: not a struct at Name: \"Much\"\n"+
+		"\t\tsecret: no such field in: match_test.structPerson\n",
+	)
+
+	s.match(match.Struct("Name", "Much"), 123, `Struct("Name", "Much"): not a struct: int(123)`)
+	s.match(match.Struct("Name", "Much"), nil, `Struct("Name", "Much"): not a struct: nil`)
+	s.match(match.Struct("Name"), person,
+		`Invalid Struct(): nameAndValues must be pairs; eg.: "Name", "lorem", "Address.City", "Prague"`)
+	s.match(match.Struct(1, "Much"), person, "Invalid Struct(): field name must be a non-empty string. Parameters: [1]")
+	s.match(match.StructExact("").Unexported(), person,
+		`Invalid StructExact(): nameAndValues must be pairs; eg.: "Name", "lorem", "Address.City", "Prague"`)
+}
+
+func (s *StructSuite) TestStructExact() {
+	s.S.Equal(`StructExact("Name", "Much")`, match.StructExact("Name", "Much").String())
+
+	person := s.person()
+
+	s.match(match.StructExact("Name", "Much", "Age", 42, "Address", match.Not(match.Nil())), person)
+	s.match(match.StructExact("Name", "Much", "Age", 42, "Address.City", "Prague", "Address.Street", "Main"), person)
+	s.match(match.StructExact("Name", "Much", "Age", 42, "Address", match.Any(), "secret", "hidden").Unexported(), person)
+
+	ok, desc := match.StructExact("Name", "Much", "Address.City", "Prague").Matches(person)
+	s.S.False(ok)
+	s.S.Contains(desc, internal.DiffPrefix+
+		"\t\tAge: unexpected non-zero value: 42\n"+
+		"\t\tAddress.Street: unexpected non-zero value: \"Main\"\n",
+	)
+
+	ok, desc = match.StructExact("Name", "Much", "Age", 42, "Address", match.Any()).Unexported().Matches(person)
+	s.S.False(ok)
+	s.S.Contains(desc, internal.DiffPrefix+"\t\tsecret: unexpected non-zero value: \"hidden\"\n")
+}
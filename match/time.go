@@ -0,0 +1,157 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+}
+
+func WithinDuration(expected time.Time, delta time.Duration) Matcher {
+	return timeMatcher{
+		str: fmt.Sprintf("WithinDuration(%s, %s)", formatValue(expected), delta),
+		fn: func(actual time.Time) string {
+			return withinDuration(expected, actual, delta)
+		},
+	}
+}
+
+func Before(t time.Time) Matcher {
+	return timeMatcher{
+		str: fmt.Sprintf("Before(%s)", formatValue(t)),
+		fn: func(actual time.Time) string {
+			if !actual.Before(t) {
+				return "not before"
+			}
+
+			return ""
+		},
+	}
+}
+
+func After(t time.Time) Matcher {
+	return timeMatcher{
+		str: fmt.Sprintf("After(%s)", formatValue(t)),
+		fn: func(actual time.Time) string {
+			if !actual.After(t) {
+				return "not after"
+			}
+
+			return ""
+		},
+	}
+}
+
+func TimeBetween(lower, upper time.Time) Matcher {
+	return timeMatcher{
+		str: fmt.Sprintf("TimeBetween(%s, %s)", formatValue(lower), formatValue(upper)),
+		fn: func(actual time.Time) string {
+			if actual.Before(lower) || actual.After(upper) {
+				return "not in range"
+			}
+
+			return ""
+		},
+	}
+}
+
+func SameInstant(t time.Time) Matcher {
+	return timeMatcher{
+		str: fmt.Sprintf("SameInstant(%s)", formatValue(t)),
+		fn: func(actual time.Time) string {
+			if !actual.Equal(t) {
+				return fmt.Sprintf("different instant (off by %s)", actual.Sub(t))
+			}
+
+			return ""
+		},
+	}
+}
+
+func RecentlyOn(clock Clock, delta time.Duration) Matcher {
+	return timeMatcher{
+		str: fmt.Sprintf("RecentlyOn(clock, %s)", delta),
+		fn: func(actual time.Time) string {
+			now := clock.Now()
+
+			if actual.After(now) {
+				return fmt.Sprintf("in the future of %s", formatValue(now))
+			}
+
+			return withinDuration(now, actual, delta)
+		},
+	}
+}
+
+func DurationBetween(lower, upper time.Duration) Matcher {
+	return durationMatcher{lower: lower, upper: upper}
+}
+
+type timeMatcher struct {
+	str string
+	fn  func(actual time.Time) string
+}
+
+func (m timeMatcher) Matches(actual any) (ok bool, desc string) {
+	vActual := indirect(reflectV(actual))
+	for vActual.Kind() == reflect.Pointer && !vActual.IsNil() {
+		vActual = vActual.Elem()
+	}
+
+	if !vActual.IsValid() || vActual.Type() != reflectT(time.Time{}) || !vActual.CanInterface() {
+		return false, fmt.Sprintf("%s: not a time.Time: %T(%s)", m.String(), actual, formatValue(actual))
+	}
+
+	tActual := vActual.Interface().(time.Time)
+
+	if reason := m.fn(tActual); reason != "" {
+		return false, fmt.Sprintf("%s: %s: %s", m.String(), reason, formatValue(tActual))
+	}
+
+	return true, ""
+}
+
+func (m timeMatcher) String() string {
+	return m.str
+}
+
+func withinDuration(expected, actual time.Time, delta time.Duration) string {
+	difference := expected.Sub(actual)
+	if difference < 0 {
+		difference = -difference
+	}
+
+	if difference > delta {
+		return fmt.Sprintf("difference %s exceeds %s", difference, delta)
+	}
+
+	return ""
+}
+
+type durationMatcher struct {
+	lower, upper time.Duration
+}
+
+func (m durationMatcher) Matches(actual any) (ok bool, desc string) {
+	vActual := indirect(reflectV(actual))
+	if vActual.Kind() == reflect.Pointer && !vActual.IsNil() {
+		vActual = vActual.Elem()
+	}
+
+	if !vActual.IsValid() || vActual.Type() != reflectT(time.Duration(0)) {
+		return false, fmt.Sprintf("%s: not a time.Duration: %T(%s)", m.String(), actual, formatValue(actual))
+	}
+
+	if d := time.Duration(vActual.Int()); d < m.lower || d > m.upper {
+		return false, fmt.Sprintf("%s: not in range: %s", m.String(), d)
+	}
+
+	return true, ""
+}
+
+func (m durationMatcher) String() string {
+	return fmt.Sprintf("DurationBetween(%s, %s)", m.lower, m.upper)
+}
@@ -0,0 +1,110 @@
+package match_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/match"
+	"github.com/jonboulle/clockwork"
+)
+
+func TestTimeSuite(t *testing.T) {
+	muchtest.Run(t, new(TimeSuite))
+}
+
+type TimeSuite struct {
+	pkgSuite
+}
+
+var reference = time.Date(2022, 12, 24, 18, 30, 0, 0, time.UTC)
+
+func (s *TimeSuite) TestWithinDuration() {
+	s.S.Equal("WithinDuration(2022-12-24 18:30:00, 1s)", match.WithinDuration(reference, time.Second).String())
+
+	s.match(match.WithinDuration(reference, time.Second), reference)
+	s.match(match.WithinDuration(reference, time.Second), reference.Add(time.Second))
+	s.match(match.WithinDuration(reference, time.Second), reference.Add(-time.Second))
+	s.match(match.WithinDuration(reference, time.Second), &reference)
+	s.match(match.WithinDuration(reference, time.Second), reference.Add(-2*time.Second),
+		"WithinDuration(2022-12-24 18:30:00, 1s): difference 2s exceeds 1s: 2022-12-24 18:29:58")
+	s.match(match.WithinDuration(reference, time.Second), "2022-12-24",
+		`WithinDuration(2022-12-24 18:30:00, 1s): not a time.Time: string("2022-12-24")`)
+	s.match(match.WithinDuration(reference, time.Second), nil,
+		"WithinDuration(2022-12-24 18:30:00, 1s): not a time.Time: <nil>(nil)")
+}
+
+func (s *TimeSuite) TestBeforeAfter() {
+	s.S.Equal("Before(2022-12-24 18:30:00)", match.Before(reference).String())
+	s.S.Equal("After(2022-12-24 18:30:00)", match.After(reference).String())
+
+	s.match(match.Before(reference), reference.Add(-time.Nanosecond))
+	s.match(match.Before(reference), reference, "Before(2022-12-24 18:30:00): not before: 2022-12-24 18:30:00")
+	s.match(match.After(reference), reference.Add(time.Millisecond))
+	s.match(match.After(reference), reference.Add(-time.Millisecond),
+		"After(2022-12-24 18:30:00): not after: 2022-12-24 18:29:59.999")
+}
+
+func (s *TimeSuite) TestTimeBetween() {
+	matcher := match.TimeBetween(reference, reference.Add(time.Hour))
+
+	s.S.Equal("TimeBetween(2022-12-24 18:30:00, 2022-12-24 19:30:00)", matcher.String())
+
+	s.match(matcher, reference)
+	s.match(matcher, reference.Add(time.Minute))
+	s.match(matcher, reference.Add(time.Hour))
+	s.match(matcher, reference.Add(-time.Minute),
+		"TimeBetween(2022-12-24 18:30:00, 2022-12-24 19:30:00): not in range: 2022-12-24 18:29:00")
+}
+
+func (s *TimeSuite) TestSameInstant() {
+	prague := time.FixedZone("Prague", 3600)
+
+	s.S.Equal("SameInstant(2022-12-24 18:30:00)", match.SameInstant(reference).String())
+
+	now := time.Now()
+
+	s.match(match.SameInstant(reference), reference.In(prague))
+	s.match(match.SameInstant(now), now.Round(0))
+	s.match(match.SameInstant(reference), reference.Add(time.Minute).In(prague),
+		"SameInstant(2022-12-24 18:30:00): different instant (off by 1m0s): 2022-12-24 18:31:00")
+}
+
+func (s *TimeSuite) TestRecentlyOn() {
+	clock := clockwork.NewFakeClockAt(reference)
+
+	s.S.Equal("RecentlyOn(clock, 1m0s)", match.RecentlyOn(clock, time.Minute).String())
+
+	s.match(match.RecentlyOn(clock, time.Minute), reference)
+	s.match(match.RecentlyOn(clock, time.Minute), reference.Add(-time.Minute))
+
+	clock.Advance(time.Hour)
+
+	s.match(match.RecentlyOn(clock, time.Minute), reference,
+		"RecentlyOn(clock, 1m0s): difference 1h0m0s exceeds 1m0s: 2022-12-24 18:30:00")
+	s.match(match.RecentlyOn(clock, time.Minute), reference.Add(2*time.Hour),
+		"RecentlyOn(clock, 1m0s): in the future of 2022-12-24 19:30:00: 2022-12-24 20:30:00")
+}
+
+func (s *TimeSuite) TestRecently() {
+	clock := s.S.ClockAt(reference)
+
+	s.match(s.S.Recently(time.Second), reference)
+
+	clock.Advance(time.Minute)
+
+	s.match(s.S.Recently(time.Second), reference,
+		"RecentlyOn(clock, 1s): difference 1m0s exceeds 1s: 2022-12-24 18:30:00")
+}
+
+func (s *TimeSuite) TestDurationBetween() {
+	s.S.Equal("DurationBetween(1s, 1m0s)", match.DurationBetween(time.Second, time.Minute).String())
+
+	s.match(match.DurationBetween(time.Second, time.Minute), time.Second)
+	s.match(match.DurationBetween(time.Second, time.Minute), 30*time.Second)
+	s.match(match.DurationBetween(time.Second, time.Minute), time.Minute)
+	s.match(match.DurationBetween(time.Second, time.Minute), time.Hour,
+		"DurationBetween(1s, 1m0s): not in range: 1h0m0s")
+	s.match(match.DurationBetween(time.Second, time.Minute), 5,
+		"DurationBetween(1s, 1m0s): not a time.Duration: int(5)")
+}
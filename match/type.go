@@ -0,0 +1,55 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+)
+
+func Type(expected any) Matcher {
+	return typeMatcher{t: reflectT(expected)}
+}
+
+func Implements(iface any) Matcher {
+	t := reflectT(iface)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Interface {
+		return matcherErr("Invalid Implements(): iface must be a nil pointer to an interface; eg.: (*error)(nil)", iface)
+	}
+
+	return typeMatcher{t: t.Elem(), implements: true}
+}
+
+type typeMatcher struct {
+	t          reflect.Type
+	implements bool
+}
+
+func (m typeMatcher) Matches(actual any) (ok bool, desc string) {
+	tActual := reflectT(actual)
+
+	if m.implements {
+		if tActual != nil && tActual.Implements(m.t) {
+			return true, ""
+		}
+
+		return false, fmt.Sprintf("%s: not implemented by: %T(%s)", m.String(), actual, formatValue(actual))
+	}
+
+	if tActual == m.t {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("%s: different type: %T(%s)", m.String(), actual, formatValue(actual))
+}
+
+func (m typeMatcher) String() string {
+	name := "nil"
+	if m.t != nil {
+		name = stringAny(m.t.String())
+	}
+
+	if m.implements {
+		return fmt.Sprintf("Implements(%s)", name)
+	}
+
+	return fmt.Sprintf("Type(%s)", name)
+}
@@ -0,0 +1,40 @@
+package match_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestTypeSuite(t *testing.T) {
+	muchtest.Run(t, new(TypeSuite))
+}
+
+type TypeSuite struct {
+	pkgSuite
+}
+
+func (s *TypeSuite) TestType() {
+	s.S.Equal("Type(int)", match.Type(0).String())
+	s.S.Equal("Type(nil)", match.Type(nil).String())
+	s.S.Equal("Type([]any)", match.Type([]any{}).String())
+
+	s.match(match.Type(0), 123)
+	s.match(match.Type(nil), nil)
+	s.match(match.Type(&stringer{}), &stringer{Text: "Much"})
+	s.match(match.Type(0), int64(123), "Type(int): different type: int64(123)")
+	s.match(match.Type(0), nil, "Type(int): different type: <nil>(nil)")
+}
+
+func (s *TypeSuite) TestImplements() {
+	s.S.Equal("Implements(fmt.Stringer)", match.Implements((*fmt.Stringer)(nil)).String())
+
+	s.match(match.Implements((*fmt.Stringer)(nil)), stringer{})
+	s.match(match.Implements((*fmt.Stringer)(nil)), &stringer{})
+	s.match(match.Implements((*fmt.Stringer)(nil)), 123, "Implements(fmt.Stringer): not implemented by: int(123)")
+	s.match(match.Implements((*fmt.Stringer)(nil)), nil, "Implements(fmt.Stringer): not implemented by: <nil>(nil)")
+	s.match(match.Implements(stringer{Text: "Much"}), stringer{},
+		"Invalid Implements(): iface must be a nil pointer to an interface; eg.: (*error)(nil) Parameters: [Much]")
+}
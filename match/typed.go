@@ -0,0 +1,142 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type T[V any] interface {
+	Match(actual V) (ok bool, desc string)
+	String() string
+}
+
+func Typed[V any](matcher any) T[V] {
+	if typed, ok := matcher.(T[V]); ok {
+		return typed
+	}
+
+	m := ToMatcher(matcher)
+
+	return typedMatcher[V]{
+		str: m.String(),
+		match: func(actual V) (bool, string) {
+			return m.Matches(actual)
+		},
+	}
+}
+
+func Untyped[V any](matcher T[V]) Matcher {
+	if m, ok := matcher.(Matcher); ok {
+		return m
+	}
+
+	return typedMatcher[V]{str: matcher.String(), match: matcher.Match}
+}
+
+func EqualTo[V any](expected V) T[V] {
+	m := typedMatcher[V]{str: fmt.Sprintf("EqualTo(%s)", formatValue(expected))}
+	equal := Equal(expected)
+
+	switch vExpected := reflectV(expected); vExpected.Kind() {
+	case reflect.Invalid, reflect.Pointer, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if isNil(vExpected) {
+			equal = Nil()
+		}
+	}
+
+	m.match = func(actual V) (bool, string) {
+		if ok, desc := equal.Matches(actual); !ok {
+			return false, m.str + strings.TrimPrefix(desc, equal.String())
+		}
+
+		return true, ""
+	}
+
+	return m
+}
+
+func FnOf[V any](fn func(actual V) bool) T[V] {
+	m := typedMatcher[V]{str: fmt.Sprintf("FnOf(%s)", reflectT(fn))}
+
+	m.match = func(actual V) (bool, string) {
+		if !fn(actual) {
+			return false, fmt.Sprintf("%s: not matched: %s", m.str, formatValue(actual))
+		}
+
+		return true, ""
+	}
+
+	return m
+}
+
+func SliceOf[V any](matchers ...T[V]) T[[]V] {
+	names := make([]string, len(matchers))
+	for i, matcher := range matchers {
+		names[i] = matcher.String()
+	}
+
+	m := typedMatcher[[]V]{str: fmt.Sprintf("SliceOf(%s)", strings.Join(names, ", "))}
+
+	m.match = func(actual []V) (bool, string) {
+		diff := &equalDiff{}
+
+		for i := 0; i < len(matchers) || i < len(actual); i++ {
+			indexPath := fmt.Sprintf("[%d]", i)
+
+			switch {
+			case i >= len(actual):
+				diff.add(indexPath, "missing value: %s", matchers[i].String())
+			case i >= len(matchers):
+				diff.add(indexPath, "unexpected value: %s", formatValue(actual[i]))
+			default:
+				if ok, desc := matchers[i].Match(actual[i]); !ok {
+					diff.add(indexPath, "%s", desc)
+				}
+			}
+		}
+
+		if len(diff.paths) == 0 {
+			return true, ""
+		}
+
+		return false, fmt.Sprintf("%s: not matched: %s%s", m.str, formatValue(actual), diff.String())
+	}
+
+	return m
+}
+
+type typedMatcher[V any] struct {
+	str   string
+	match func(actual V) (bool, string)
+}
+
+func (m typedMatcher[V]) Match(actual V) (ok bool, desc string) {
+	return m.match(actual)
+}
+
+func (m typedMatcher[V]) Matches(actual any) (ok bool, desc string) {
+	if vActual, isValue := actual.(reflect.Value); isValue && vActual.IsValid() && vActual.CanInterface() {
+		actual = vActual.Interface()
+	}
+
+	if typed, isTyped := actual.(V); isTyped {
+		return m.match(typed)
+	}
+
+	var zero V
+
+	tValue := reflectT(&zero).Elem()
+	if actual == nil {
+		switch tValue.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+			return m.match(zero)
+		}
+	}
+
+	return false, fmt.Sprintf("%s: not %s: %T(%s)", m.str, tValue, actual, formatValue(actual))
+}
+
+func (m typedMatcher[V]) String() string {
+	return m.str
+}
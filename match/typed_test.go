@@ -0,0 +1,121 @@
+package match_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/internal"
+	"github.com/grongor/go-muchtest/match"
+)
+
+func TestTypedSuite(t *testing.T) {
+	muchtest.Run(t, new(TypedSuite))
+}
+
+type TypedSuite struct {
+	pkgSuite
+}
+
+type evenMatcher struct{}
+
+func (evenMatcher) Match(actual int) (ok bool, desc string) {
+	if actual%2 != 0 {
+		return false, fmt.Sprintf("Even(): not even: %d", actual)
+	}
+
+	return true, ""
+}
+
+func (evenMatcher) String() string {
+	return "Even()"
+}
+
+func (s *TypedSuite) typedMatch(matcher match.T[int], actual int, expectedDesc string) {
+	s.T().Helper()
+
+	ok, desc := matcher.Match(actual)
+	s.S.Equal(expectedDesc == "", ok)
+	s.S.Equal(expectedDesc, desc)
+}
+
+func (s *TypedSuite) TestEqualTo() {
+	s.S.Equal("EqualTo(5)", match.EqualTo(5).String())
+	s.S.Equal(`EqualTo("Much")`, match.EqualTo("Much").String())
+
+	s.typedMatch(match.EqualTo(5), 5, "")
+	s.typedMatch(match.EqualTo(5), 6, "EqualTo(5): not equal: 6")
+
+	s.match(match.EqualTo(5).(match.Matcher), 5)
+	s.match(match.ToMatcher(match.EqualTo(5)), 6, "EqualTo(5): not equal: 6")
+	s.match(match.ToMatcher(match.EqualTo(5)), int64(5), "EqualTo(5): not int: int64(5)")
+	s.match(match.ToMatcher(match.EqualTo(5)), nil, "EqualTo(5): not int: <nil>(nil)")
+	s.match(match.ToMatcher(match.EqualTo[*int](nil)), nil)
+	s.match(match.ToMatcher(match.EqualTo[error](nil)), nil)
+}
+
+func (s *TypedSuite) TestFnOf() {
+	isPositive := match.FnOf(func(actual int) bool { return actual > 0 })
+
+	s.S.Equal("FnOf(func(int) bool)", isPositive.String())
+
+	s.typedMatch(isPositive, 1, "")
+	s.typedMatch(isPositive, -1, "FnOf(func(int) bool): not matched: -1")
+
+	s.match(match.ToMatcher(isPositive), 3)
+	s.match(match.ToMatcher(isPositive), "3", `FnOf(func(int) bool): not int: string("3")`)
+}
+
+func (s *TypedSuite) TestSliceOf() {
+	matcher := match.SliceOf(match.EqualTo(1), match.Typed[int](match.Positive()), match.T[int](evenMatcher{}))
+
+	s.S.Equal("SliceOf(EqualTo(1), Positive(), Even())", matcher.String())
+
+	ok, desc := matcher.Match([]int{1, 2, 4})
+	s.S.True(ok)
+	s.S.Empty(desc)
+
+	ok, desc = matcher.Match([]int{2, -1, 3, 8})
+	s.S.False(ok)
+	s.S.Equal("SliceOf(EqualTo(1), Positive(), Even()): not matched: []int{2, -1, 3, 8}"+internal.DiffPrefix+
+		"\t\t[0]: EqualTo(1): not equal: 2\n"+
+		"\t\t[1]: Positive(): not positive: -1\n"+
+		"\t\t[2]: Even(): not even: 3\n"+
+		"\t\t[3]: unexpected value: 8\n", desc)
+
+	ok, desc = matcher.Match([]int{1})
+	s.S.False(ok)
+	s.S.Equal("SliceOf(EqualTo(1), Positive(), Even()): not matched: []int{1}"+internal.DiffPrefix+
+		"\t\t[1]: missing value: Positive()\n"+
+		"\t\t[2]: missing value: Even()\n", desc)
+
+	s.match(match.Untyped[[]int](matcher), []int{1, 2, 4})
+	s.match(match.Untyped[[]int](matcher), []any{1, 2, 4},
+		"SliceOf(EqualTo(1), Positive(), Even()): not []int: []interface {}([]any{1, 2, 4})")
+}
+
+func (s *TypedSuite) TestAdapters() {
+	s.S.Equal("Positive()", match.Typed[int](match.Positive()).String())
+	s.S.Equal("EqualTo(1)", match.Typed[int](match.EqualTo(1)).String())
+	s.S.Equal("Equal(1)", match.Typed[int](1).String())
+
+	s.typedMatch(match.Typed[int](match.Positive()), 1, "")
+	s.typedMatch(match.Typed[int](match.Positive()), 0, "Positive(): not positive: 0")
+	s.typedMatch(match.Typed[int](evenMatcher{}), 3, "Even(): not even: 3")
+
+	even := match.Untyped[int](evenMatcher{})
+
+	s.S.Equal("Even()", even.String())
+	s.match(even, 2)
+	s.match(even, 3, "Even(): not even: 3")
+	s.match(even, "2", `Even(): not int: string("2")`)
+	s.match(match.All(even, match.Positive()), 4)
+
+	s.match(even, nil, "Even(): not int: <nil>(nil)")
+	s.match(match.Equal([]any{even}), []int{2})
+	s.match(match.Each(even), []int{2, 4})
+
+	// Only the adapters make matchers of T[V], other types with the same methods are plain values.
+	s.match(match.ToMatcher(evenMatcher{}), evenMatcher{})
+	s.match(match.Equal([]any{evenMatcher{}}), []any{evenMatcher{}})
+}
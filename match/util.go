@@ -61,6 +61,10 @@ func (m matcherErrMatcher) Stateful() Matcher {
 	return m
 }
 
+func (m matcherErrMatcher) Unexported() Matcher {
+	return m
+}
+
 func (m matcherErrMatcher) String() string {
 	return "{InvalidMatcher}"
 }
@@ -105,6 +109,19 @@ func formatValue(value any) string {
 	return builder.String()
 }
 
+func formatReflectValue(value reflect.Value) string {
+	switch {
+	case !value.IsValid():
+		return "nil"
+	case value.CanInterface():
+		return formatValue(value.Interface())
+	case value.Kind() == reflect.String:
+		return `"` + value.String() + `"`
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
 func doFormatValue(builder *strings.Builder, value any) {
 	tValue := reflectT(value)
 	t2 := reflectT(reflect.Value{})
@@ -113,6 +130,12 @@ func doFormatValue(builder *strings.Builder, value any) {
 		tValue = reflectT(value)
 	}
 
+	if matcher, ok := lookupPlaceholder(reflectV(value)); ok {
+		builder.WriteString(matcher.String())
+
+		return
+	}
+
 	if s, ok := formatString(value); ok {
 		builder.WriteString(s)
 
@@ -242,12 +265,12 @@ func doFormatValue(builder *strings.Builder, value any) {
 	case reflect.Pointer:
 		vValue := reflectV(value)
 
-		builder.WriteByte('*')
-
 		if vValue.IsNil() {
+			builder.WriteByte('(')
 			builder.WriteString(vValue.Type().String())
-			builder.WriteString("(nil)")
+			builder.WriteString(")(nil)")
 		} else {
+			builder.WriteByte('*')
 			doFormatValue(builder, vValue.Elem().Interface())
 		}
 	default:
@@ -281,6 +304,10 @@ func doToString(value any, format bool) (str string, ok bool) {
 		return fmt.Sprintf("%s(%s)", reflectV(value).Type().String(), s.String()), true
 	}
 
+	if err, ok := value.(error); ok && format && !isNil(reflectV(value)) {
+		return fmt.Sprintf("%s(%s)", reflectV(value).Type().String(), err.Error()), true
+	}
+
 	vValue := reflectV(value)
 
 	switch vValue.Kind() {
@@ -0,0 +1,142 @@
+package muchtest
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/grongor/go-muchtest/match"
+	"github.com/jonboulle/clockwork"
+)
+
+const (
+	defaultWaitFor = time.Second
+	defaultTick    = 10 * time.Millisecond
+)
+
+type PollOption func(p *poller)
+
+func WaitFor(waitFor time.Duration) PollOption {
+	return func(p *poller) {
+		p.waitFor = waitFor
+	}
+}
+
+func Tick(tick time.Duration) PollOption {
+	return func(p *poller) {
+		p.tick = tick
+	}
+}
+
+func AdvanceClock(clock clockwork.FakeClock) PollOption {
+	return func(p *poller) {
+		p.clock = clock
+	}
+}
+
+type poller struct {
+	fn      reflect.Value
+	matcher match.Matcher
+	waitFor time.Duration
+	tick    time.Duration
+	clock   clockwork.FakeClock
+	polls   int
+	elapsed time.Duration
+}
+
+func newPoller(fn any, matcher match.Matcher, options []PollOption) (*poller, string) {
+	vFn := reflect.ValueOf(fn)
+	if vFn.Kind() != reflect.Func || vFn.IsNil() || vFn.Type().NumIn() != 0 || vFn.Type().NumOut() != 1 {
+		return nil, fmt.Sprintf("fn must be a func() T, got %T", fn)
+	}
+
+	p := &poller{fn: vFn, matcher: matcher, waitFor: defaultWaitFor, tick: defaultTick}
+
+	for _, option := range options {
+		option(p)
+	}
+
+	if p.waitFor <= 0 {
+		return nil, fmt.Sprintf("waitFor must be positive, got %s", p.waitFor)
+	}
+
+	if p.tick <= 0 {
+		return nil, fmt.Sprintf("tick must be positive, got %s", p.tick)
+	}
+
+	return p, ""
+}
+
+func (p *poller) poll(until bool) (ok bool, desc string) {
+	if p.clock != nil {
+		return p.pollFakeClock(until)
+	}
+
+	start := time.Now()
+
+	timer := time.NewTimer(p.waitFor)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(p.tick)
+	defer ticker.Stop()
+
+	for {
+		p.elapsed = time.Since(start)
+
+		if ok, desc = p.check(); ok == until {
+			return ok, desc
+		}
+
+		select {
+		case <-timer.C:
+			return ok, desc
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *poller) pollFakeClock(until bool) (ok bool, desc string) {
+	start := p.clock.Now()
+
+	for {
+		p.elapsed = p.clock.Since(start)
+
+		if ok, desc = p.check(); ok == until || p.elapsed >= p.waitFor {
+			return ok, desc
+		}
+
+		p.clock.Advance(p.tick)
+
+		// Let goroutines woken up by the clock run before the next poll.
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (p *poller) check() (ok bool, desc string) {
+	p.polls++
+
+	return p.matcher.Matches(p.fn.Call(nil)[0].Interface())
+}
+
+func (p *poller) pollsString() string {
+	if p.polls == 1 {
+		return "1 poll"
+	}
+
+	return fmt.Sprintf("%d polls", p.polls)
+}
+
+func splitPollOptions(optionsAndMessageAndArgs []any) ([]PollOption, []any) {
+	var options []PollOption
+
+	for i, arg := range optionsAndMessageAndArgs {
+		option, ok := arg.(PollOption)
+		if !ok {
+			return options, optionsAndMessageAndArgs[i:]
+		}
+
+		options = append(options, option)
+	}
+
+	return options, nil
+}
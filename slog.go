@@ -0,0 +1,350 @@
+package muchtest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"runtime"
+	"time"
+
+	"github.com/grongor/go-muchtest/match"
+	"go.uber.org/zap/zapcore"
+)
+
+const slogPrefix = "slog: "
+
+func NewSlog(t TestingT) *Slog {
+	return &Slog{t: t, core: newLogCore(t, slogPrefix)}
+}
+
+// Slog adapts slog to the logCore: the records of its logger are recorded as LogRecords, and the assertions take slog
+// levels.
+type Slog struct {
+	t    TestingT
+	core *logCore
+
+	logger     *slog.Logger
+	level      LogLevel
+	encoder    zapcore.Encoder
+	suiteLevel *slog.Level
+}
+
+func (s *Slog) Get() *slog.Logger {
+	return s.GetAt(slog.LevelInfo)
+}
+
+func (s *Slog) GetAt(level slog.Level) *slog.Logger {
+	s.t.Helper()
+
+	// The suite has already created the logger for its logger fields, so record into it instead.
+	if s.suiteLevel != nil {
+		if level < *s.suiteLevel {
+			s.core.reportError(fmt.Sprintf(
+				"GetAt(%s): the suite's logger fields are observed from the %s level",
+				slogToLogLevel(level), slogToLogLevel(*s.suiteLevel),
+			))
+		}
+	} else {
+		s.core.initialize(&logStore{})
+		s.level = slogToLogLevel(level)
+	}
+
+	s.logger = slog.New(&slogHandler{recorder: s.recorder(), level: level})
+
+	return s.logger
+}
+
+func (s *Slog) recorder() logRecorder {
+	return logRecorder{store: s.core.store, level: s.level}
+}
+
+func (s *Slog) Handler() slog.Handler {
+	s.t.Helper()
+
+	if s.logger == nil {
+		s.core.reportError("First call Get() or GetAt() methods")
+	}
+
+	return s.logger.Handler()
+}
+
+func (s *Slog) Encoder(encoder zapcore.Encoder) *Slog {
+	s.t.Helper()
+
+	s.core.checkNotCreated(s.suiteLevel != nil, "Encoder")
+
+	s.encoder = encoder
+
+	return s
+}
+
+// Encoded returns the records as the zap encoder (JSON, by default) encodes them, see Zap.Encoded().
+func (s *Slog) Encoded() *ZapEncoded {
+	s.t.Helper()
+
+	if s.core.state == logCreated {
+		s.core.reportError("First call Get() or GetAt() methods")
+	}
+
+	return newZapEncoded(s.core, s.encoder)
+}
+
+func (s *Slog) Debug(message ...any) *slogEntryAssert {
+	return s.newAssert().setLevelAndMessage(LogDebug, message)
+}
+
+func (s *Slog) Info(message ...any) *slogEntryAssert {
+	return s.newAssert().setLevelAndMessage(LogInfo, message)
+}
+
+func (s *Slog) Warn(message ...any) *slogEntryAssert {
+	return s.newAssert().setLevelAndMessage(LogWarn, message)
+}
+
+func (s *Slog) Error(message ...any) *slogEntryAssert {
+	return s.newAssert().setLevelAndMessage(LogError, message)
+}
+
+func (s *Slog) newAssert() *slogEntryAssert {
+	s.t.Helper()
+
+	return &slogEntryAssert{a: s.core.newAssert(nil), s: s}
+}
+
+func (s *Slog) AssertNext(level slog.Level, message any, ctxKeyAndValues ...any) *Slog {
+	s.t.Helper()
+	s.core.assertNext(nil, slogToLogLevel(level), message, ctxKeyAndValues)
+
+	return s
+}
+
+func (s *Slog) SkipNext(n ...int) *Slog {
+	s.t.Helper()
+	s.core.skipNext(n)
+
+	return s
+}
+
+func (s *Slog) AssertNoNextEntry() {
+	s.t.Helper()
+	s.core.assertNoNextEntry()
+}
+
+func (s *Slog) IgnoreMissingContext(partial ...bool) *Slog {
+	s.t.Helper()
+	s.core.ignoreMissingContext(partial)
+
+	return s
+}
+
+func (s *Slog) Orderless(orderless ...bool) *Slog {
+	s.t.Helper()
+	s.core.setOrderless(orderless)
+
+	return s
+}
+
+func (s *Slog) Within(waitFor time.Duration, options ...PollOption) *Slog {
+	s.t.Helper()
+	s.core.setWithin(waitFor, options)
+
+	return s
+}
+
+func (s *Slog) Ignore(level slog.Level, message any, ctxKeyAndValues ...any) *Slog {
+	s.t.Helper()
+	s.core.ignore(slogToLogLevel(level), message, ctxKeyAndValues)
+
+	return s
+}
+
+func (s *Slog) Count(level slog.Level, message any, ctxKeyAndValues ...any) int {
+	s.t.Helper()
+
+	return s.core.count(slogToLogLevel(level), message, ctxKeyAndValues)
+}
+
+func (s *Slog) AssertCount(n int, level slog.Level, message any, ctxKeyAndValues ...any) *Slog {
+	s.t.Helper()
+	s.core.assertCount(n, slogToLogLevel(level), message, ctxKeyAndValues)
+
+	return s
+}
+
+func (s *Slog) AssertAtMost(n int, level slog.Level, message any, ctxKeyAndValues ...any) *Slog {
+	s.t.Helper()
+	s.core.assertAtMost(n, slogToLogLevel(level), message, ctxKeyAndValues)
+
+	return s
+}
+
+func (s *Slog) AssertNone(level slog.Level, message any, ctxKeyAndValues ...any) *Slog {
+	s.t.Helper()
+	s.core.assertNone(slogToLogLevel(level), message, ctxKeyAndValues)
+
+	return s
+}
+
+func (s *Slog) MayContainMoreEntries() {
+	s.core.loose = true
+}
+
+// slogEntryAssert is the logEntryAssert limited to what slog records, returning to Slog when terminated.
+type slogEntryAssert struct {
+	a *logEntryAssert
+	s *Slog
+}
+
+func (a *slogEntryAssert) setLevelAndMessage(level LogLevel, message []any) *slogEntryAssert {
+	a.a.setLevelAndMessage(level, message)
+
+	return a
+}
+
+func (a *slogEntryAssert) Msgf(template string, args ...any) *slogEntryAssert {
+	a.a.setMessage(match.Format(template, args...))
+
+	return a
+}
+
+func (a *slogEntryAssert) Caller(caller any) *slogEntryAssert {
+	a.a.setCaller(caller)
+
+	return a
+}
+
+func (a *slogEntryAssert) Time(time any) *slogEntryAssert {
+	a.a.setTime(time)
+
+	return a
+}
+
+func (a *slogEntryAssert) With(ctxKeyAndValues ...any) *slogEntryAssert {
+	a.a.setWith(ctxKeyAndValues)
+
+	return a
+}
+
+func (a *slogEntryAssert) IgnoreMissingCtx(ctxKeyAndValues ...any) *Slog {
+	a.s.t.Helper()
+	a.a.ignoreMissingCtx(ctxKeyAndValues)
+
+	return a.s
+}
+
+func (a *slogEntryAssert) CtxErr(err any) *Slog {
+	a.s.t.Helper()
+
+	return a.IgnoreMissingCtx("error", err)
+}
+
+func (a *slogEntryAssert) Ctx(ctxKeyAndValues ...any) *Slog {
+	a.s.t.Helper()
+	a.a.ctx(ctxKeyAndValues)
+
+	return a.s
+}
+
+func (a *slogEntryAssert) NoCtx() *Slog {
+	a.s.t.Helper()
+	a.a.noCtx()
+
+	return a.s
+}
+
+func (a *slogEntryAssert) IgnoreCtx() *Slog {
+	a.s.t.Helper()
+	a.a.ignoreCtx()
+
+	return a.s
+}
+
+func (a *slogEntryAssert) String() string {
+	return a.a.String()
+}
+
+func slogToLogLevel(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return LogDebug
+	case level < slog.LevelWarn:
+		return LogInfo
+	case level < slog.LevelError:
+		return LogWarn
+	default:
+		return LogError
+	}
+}
+
+func init() {
+	RegisterLogAdapter("slog", func(recorder LogRecorder) any {
+		return slog.New(&slogHandler{recorder: recorder, level: slog.Level(math.MinInt)})
+	})
+}
+
+type slogHandler struct {
+	recorder LogRecorder
+	level    slog.Level
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level && h.recorder.Enabled(slogToLogLevel(level))
+}
+
+func (h *slogHandler) Handle(_ context.Context, record slog.Record) error {
+	logRecord := LogRecord{
+		Time:    record.Time,
+		Level:   slogToLogLevel(record.Level),
+		Message: record.Message,
+		Attrs:   make([]LogAttr, 0, record.NumAttrs()),
+	}
+
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		logRecord.CallerFile = frame.File
+		logRecord.CallerLine = frame.Line
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		logRecord.Attrs = append(logRecord.Attrs, slogAttr(attr))
+
+		return true
+	})
+
+	return h.recorder.Record(logRecord)
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	logAttrs := make([]LogAttr, len(attrs))
+	for i, attr := range attrs {
+		logAttrs[i] = slogAttr(attr)
+	}
+
+	return &slogHandler{recorder: h.recorder.With(logAttrs), level: h.level}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &slogHandler{recorder: h.recorder.WithGroup(name), level: h.level}
+}
+
+func slogAttr(attr slog.Attr) LogAttr {
+	value := attr.Value.Resolve()
+
+	if value.Kind() == slog.KindGroup {
+		group := value.Group()
+		attrs := make([]LogAttr, len(group))
+
+		for i, attr := range group {
+			attrs[i] = slogAttr(attr)
+		}
+
+		return LogAttr{Key: attr.Key, Value: attrs}
+	}
+
+	return LogAttr{Key: attr.Key, Value: value.Any()}
+}
@@ -0,0 +1,164 @@
+package muchtest_test
+
+import (
+	"log/slog"
+	"regexp"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/internal"
+	"github.com/grongor/go-muchtest/match"
+	"github.com/grongor/go-muchtest/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSlogSuite(t *testing.T) {
+	muchtest.Run(t, new(SlogSuite))
+}
+
+type SlogSuite struct {
+	muchtest.Suite
+
+	TestingT *mocks.TestingT
+
+	sl *muchtest.Slog
+}
+
+func (s *SlogSuite) BeforeTest(suiteName, testName string) {
+	s.sl = muchtest.NewSlog(s.TestingT)
+
+	s.TestingT.EXPECT().Helper().Maybe()
+}
+
+func (s *SlogSuite) TestAssertNext() {
+	logger := s.sl.GetAt(slog.LevelDebug)
+
+	logger.Debug("much", "wow", 1)
+	logger.Info("such", "test", true, "took", time.Second)
+	logger.Warn("very")
+	logger.Error("amaze", "err", "doge")
+
+	s.sl.AssertNext(slog.LevelDebug, "much", "wow", 1).
+		AssertNext(slog.LevelInfo, "such", "test", true, "took", time.Second)
+	s.sl.Warn("very").NoCtx()
+	s.sl.Error(match.Prefix("am")).IgnoreMissingCtx("err", "doge")
+
+	logger.Info("such")
+	s.expectErr(`Equal(muchtest.LogLevel(warn)): not equal: muchtest.LogLevel(info)`, func() {
+		s.sl.AssertNext(slog.LevelWarn, "such")
+	})
+}
+
+func (s *SlogSuite) TestGroups() {
+	logger := s.sl.Get()
+
+	logger.Info("much", slog.Group("request", slog.Int("id", 42), slog.Group("user", "name", "doge")))
+	logger.WithGroup("http").With("method", "GET").Info("wow", "status", 200)
+	logger.With("requestID", "abc").Info("such", slog.Group("", "inline", true), slog.Group("empty"))
+
+	s.sl.Info("much").Ctx("request.id", 42, "request.user.name", "doge")
+	s.sl.Info("wow").Ctx("http.method", "GET", "http.status", 200)
+	s.sl.Info("such").With("requestID", "abc").Ctx("inline", true)
+}
+
+func (s *SlogSuite) TestOrderlessAndIgnore() {
+	logger := s.sl.GetAt(slog.LevelDebug)
+	s.sl.Ignore(slog.LevelDebug, "heartbeat").Orderless()
+
+	logger.Info("much")
+	logger.Debug("heartbeat")
+	logger.Warn("wow", "a", 1, "b", 2)
+
+	s.sl.IgnoreMissingContext()
+	s.sl.Warn("wow").Ctx("b", 2)
+	s.sl.Info("much").NoCtx()
+
+	s.S.Equal(1, s.sl.Count(slog.LevelDebug, "heartbeat"))
+	s.sl.AssertNone(slog.LevelError, nil)
+}
+
+func (s *SlogSuite) TestCount() {
+	logger := s.sl.Get()
+
+	logger.Warn("retry", "attempt", 1)
+	logger.Warn("retry", "attempt", 2)
+	logger.Error("retry", "attempt", 3)
+
+	s.sl.AssertCount(2, slog.LevelWarn, "retry").
+		AssertAtMost(1, slog.LevelError, "retry").
+		AssertNone(slog.LevelInfo, nil, "attempt", 4).
+		Warn("retry").Ctx("attempt", 1).
+		Warn("retry").Ctx("attempt", 2).
+		Error("retry").Ctx("attempt", 3).
+		AssertNoNextEntry()
+
+	s.expectErr("AssertAtMost(1, Warn): 2 entries matched:", func() {
+		s.sl.AssertAtMost(1, slog.LevelWarn, "retry")
+	})
+}
+
+func (s *SlogSuite) TestAssertNoNextEntry() {
+	logger := s.sl.Get()
+
+	logger.Info("much")
+	logger.Warn("wow", "such", "doge")
+
+	s.sl.SkipNext()
+	s.expectErr("AssertNoNextEntry(): there are entries available (1)", func() { s.sl.AssertNoNextEntry() })
+
+	s.sl.Warn("wow").Ctx("such", "doge").AssertNoNextEntry()
+}
+
+func (s *SlogSuite) TestSuiteIgnores() {
+	s.S.IgnoreZap(zapcore.DebugLevel, "heartbeat")
+
+	s.S.Slog().GetAt(slog.LevelDebug).Debug("heartbeat")
+}
+
+func (s *SlogSuite) TestCaller() {
+	s.sl.Get().Info("much")
+
+	s.sl.Info("much").Caller(match.Suffix("slog_test.go")).NoCtx()
+}
+
+func (s *SlogSuite) TestEncoded() {
+	s.sl.Get().Info("much", "wow", 1)
+
+	s.sl.Encoded().Line(0).JSON(map[string]any{
+		"level": "info", "ts": match.Positive(), "caller": match.Regexp(`slog_test\.go:\d+$`), "msg": "much", "wow": 1,
+	})
+	s.sl.MayContainMoreEntries()
+}
+
+func (s *SlogSuite) TestSuiteSlog() {
+	s.S.Slog().Get().Info("much")
+	s.S.Slog().Info("much").NoCtx()
+}
+
+func (s *SlogSuite) expectErr(desc string, fn func()) {
+	s.T().Helper()
+
+	s.TestingT.EXPECT().Errorf("\n%s", mock.MatchedBy(func(actual string) bool {
+		return regexp.MustCompile(`(?s)\s*Error Trace:(.*?)Error:\s+muchtest: slog: ` + regexp.QuoteMeta(desc)).
+			MatchString(internal.TrimDiff(actual))
+	})).Once()
+
+	s.TestingT.EXPECT().FailNow().Once().Run(func(mock.Arguments) { runtime.Goexit() })
+
+	done := make(chan bool)
+
+	go func() {
+		defer close(done)
+
+		fn()
+
+		done <- true
+	}()
+
+	if notExited := <-done; notExited {
+		s.Fail("expected goroutine to exit")
+	}
+}
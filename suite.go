@@ -1,18 +1,24 @@
 package muchtest
 
 import (
+	"flag"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/grongor/go-muchtest/internal"
+	"github.com/grongor/go-muchtest/match"
 	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zapcore"
 )
 
 const prefix = "muchtest: "
@@ -50,6 +56,10 @@ func (s *Suite) SetupSuite() {
 	s.S.SetupSuite()
 }
 
+func (s *Suite) TearDownSuite() {
+	s.S.TearDownSuite()
+}
+
 func (s *Suite) SetupTest() {
 	s.S.SetupTest()
 }
@@ -66,19 +76,42 @@ type ourSuite struct {
 	suite.TestingSuite
 	Assertions
 
-	self  TestingSuite
-	vSelf reflect.Value
-	t     *testing.T
-	z     *Zap
-	clock clockwork.FakeClock
-	mocks []reflect.Value
-	mu    sync.Mutex
+	self    TestingSuite
+	vSelf   reflect.Value
+	t       *testing.T
+	z       *Zap
+	sl      *Slog
+	clock   clockwork.FakeClock
+	mocks   []suiteMock
+	mockTs  []*mockT
+	loggers []suiteLogger
+	suts    []suiteSUT
+	tests   map[string]bool
+	mu      sync.Mutex
+
+	logIgnores []logIgnore
+	providers  map[reflect.Type]reflect.Value
 }
 
 func (s *ourSuite) Zap() *Zap {
 	return s.z
 }
 
+func (s *ourSuite) Slog() *Slog {
+	return s.sl
+}
+
+// IgnoreZap ignores the matching entries in this and all the following tests, both in Zap() and Slog().
+func (s *ourSuite) IgnoreZap(level zapcore.Level, message any, ctxKeyAndValues ...any) {
+	ignore := logIgnore{level: LogLevel(level), message: message, ctxKeyAndValues: zapCtxArgs(ctxKeyAndValues)}
+	s.logIgnores = append(s.logIgnores, ignore)
+
+	if s.z != nil {
+		s.z.core.ignores = append(s.z.core.ignores, ignore)
+		s.sl.core.ignores = append(s.sl.core.ignores, ignore)
+	}
+}
+
 func (s *ourSuite) Clock() clockwork.FakeClock {
 	if s.clock == nil {
 		s.clock = clockwork.NewFakeClock()
@@ -97,6 +130,10 @@ func (s *ourSuite) ClockAt(t time.Time) clockwork.FakeClock {
 	return s.clock
 }
 
+func (s *ourSuite) Recently(delta time.Duration) match.Matcher {
+	return match.RecentlyOn(s.Clock(), delta)
+}
+
 func (s *ourSuite) Run(name string, fn func()) bool {
 	oldT := s.T()
 	defer s.SetT(oldT)
@@ -132,24 +169,32 @@ func (s *ourSuite) SetT(t *testing.T) {
 	s.t = t
 	s.Assertions.t = t
 	s.z = NewZap(t)
+	s.z.core.ignores = append(s.z.core.ignores, s.logIgnores...)
+	s.sl = NewSlog(t)
+	s.sl.core.ignores = append(s.sl.core.ignores, s.logIgnores...)
 }
 
 func (s *ourSuite) SetupSuite() {
+	s.setupProviders()
+
 	fields := s.vSelf.NumField()
 
 	for i := 0; i < fields; i++ {
 		field := s.vSelf.Field(i)
-		if field.Kind() != reflect.Ptr || !field.CanSet() {
+
+		if sut, ok := s.sutField(field, s.vSelf.Type().Field(i)); ok {
+			s.suts = append(s.suts, sut)
+
 			continue
 		}
 
-		fieldType := field.Type().Elem()
+		if logger, ok := s.loggerField(field, s.vSelf.Type().Field(i)); ok {
+			s.loggers = append(s.loggers, logger)
 
-		if mockField, ok := fieldType.FieldByName("Mock"); !ok || mockField.Type != reflect.TypeOf(mock.Mock{}) {
 			continue
 		}
 
-		s.mocks = append(s.mocks, field)
+		s.mocks = append(s.mocks, s.mockFields(field, s.vSelf.Type().Field(i))...)
 	}
 }
 
@@ -157,12 +202,15 @@ func (s *ourSuite) SetupTest() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	argsTestingT := []reflect.Value{reflect.ValueOf(s.t)}
+	t := &mockT{T: s.t}
+	s.mockTs = append(s.mockTs, t)
 
-	for _, field := range s.mocks {
-		field.Set(reflect.New(field.Type().Elem()))
-		field.MethodByName("Test").Call(argsTestingT)
+	for _, m := range s.mocks {
+		m.create(t)
 	}
+
+	s.setupLoggers()
+	s.setupSUTs()
 }
 
 func (s *ourSuite) TearDownTest() {
@@ -171,26 +219,71 @@ func (s *ourSuite) TearDownTest() {
 
 	s.clock = nil
 
-	argsTestingT := []reflect.Value{reflect.ValueOf(s.t)}
+	if s.tests == nil {
+		s.tests = map[string]bool{}
+	}
+
+	s.tests[s.t.Name()] = !s.t.Failed() && !s.t.Skipped()
+
+	if len(s.mockTs) != 0 {
+		t := s.mockTs[len(s.mockTs)-1]
+		s.mockTs = s.mockTs[:len(s.mockTs)-1]
+
+		t.cleanup()
+	}
+
+	s.checkLogs(s.z.core)
+	s.checkLogs(s.sl.core)
+}
 
-	for _, field := range s.mocks {
-		field.MethodByName("AssertExpectations").Call(argsTestingT)
+func (s *ourSuite) checkLogs(core *logCore) {
+	for _, failure := range core.checkTeardown() {
+		assert.Fail(s.t, prefix+failure)
 	}
+}
 
-	if s.z.logger == nil {
+func (s *ourSuite) TearDownSuite() {
+	if run := flag.Lookup("test.run"); run != nil && strings.Contains(run.Value.String(), "/") {
 		return
 	}
 
-	if s.z.pending != nil {
-		assert.Fail(s.t, fmt.Sprintf("%s%sMissing call to one of the terminating methods(%s) on assert: %s",
-			prefix, zapPrefix, zapEntryAssertTerminatingMethods, s.z.pending.String()))
+	suiteName := s.t.Name()
+	suiteDir := filepath.Join(internal.SnapshotsDir, filepath.FromSlash(suiteName))
+
+	var obsolete []string
+
+	_ = filepath.WalkDir(suiteDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || internal.SnapshotUsed(path) {
+			return nil
+		}
+
+		relPath, _ := filepath.Rel(suiteDir, path)
+		testName := strings.SplitN(filepath.ToSlash(relPath), "/", 2)[0]
+
+		_, exists := reflect.TypeOf(s.self).MethodByName(testName)
+		if passed := s.tests[suiteName+"/"+testName]; passed || !exists {
+			obsolete = append(obsolete, path)
+		}
+
+		return nil
+	})
+
+	if len(obsolete) == 0 {
+		return
 	}
 
-	if !s.z.loose {
-		if message := s.z.checkNoNextEntry(); message != "" {
-			assert.Fail(s.t, prefix+zapPrefix+message)
+	if internal.UpdateSnapshots() {
+		for _, path := range obsolete {
+			if err := os.Remove(path); err != nil {
+				assert.Fail(s.t, fmt.Sprintf("%sfailed to remove obsolete snapshot: %s", prefix, err))
+			}
 		}
+
+		return
 	}
+
+	assert.Fail(s.t, fmt.Sprintf("%sobsolete snapshots found (run with -muchtest.update to remove them):\n\t%s",
+		prefix, strings.Join(obsolete, "\n\t")))
 }
 
 func Run(t *testing.T, s TestingSuite) {
@@ -0,0 +1,188 @@
+package muchtest
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const tagName = "muchtest"
+
+var (
+	zapLoggerType        = reflect.TypeOf((*zap.Logger)(nil))
+	zapSugaredLoggerType = reflect.TypeOf((*zap.SugaredLogger)(nil))
+	slogLoggerType       = reflect.TypeOf((*slog.Logger)(nil))
+)
+
+// SetupLoggersSuite is implemented by suites that configure s.S.Zap() or s.S.Slog(), e.g. their Encoder(), Options()
+// or Clock(). SetupLoggers() is called in SetupTest, before the loggers of the suite's fields are created.
+type SetupLoggersSuite interface {
+	SetupLoggers()
+}
+
+type suiteLogger struct {
+	field     reflect.Value
+	fieldName string
+	backend   string
+	level     zapcore.Level
+	slogLevel slog.Level
+	name      string
+}
+
+// loggerField recognizes suite fields that should receive a logger: *zap.Logger, *zap.SugaredLogger and
+// *slog.Logger, or any field tagged with `muchtest:"<backend>[,level=<level>][,name=<name>]"`, where the backend is
+// "zap", "slog" or the name of a registered LogAdapter. The level applies to every backend; an unknown backend fails
+// the test.
+func (s *ourSuite) loggerField(field reflect.Value, structField reflect.StructField) (suiteLogger, bool) {
+	tag, tagged := structField.Tag.Lookup(tagName)
+	options := strings.Split(tag, ",")
+
+	logger := suiteLogger{field: field, fieldName: structField.Name, backend: options[0], level: zapcore.InfoLevel}
+
+	if logger.backend == "" {
+		switch field.Type() {
+		case zapLoggerType, zapSugaredLoggerType:
+			logger.backend = "zap"
+		case slogLoggerType:
+			logger.backend = "slog"
+		default:
+			return logger, false
+		}
+	} else if !isLogBackend(logger.backend) {
+		// Mock tags are key=value pairs, anything else names a logger backend.
+		if logger.backend != "-" && !strings.Contains(logger.backend, "=") {
+			s.fieldError(structField.Name, "unknown logger backend %q, supported: %s",
+				logger.backend, strings.Join(append([]string{"zap"}, logAdapterNames()...), ", "))
+		}
+
+		return logger, false
+	}
+
+	if !field.CanSet() {
+		if tagged {
+			s.fieldError(structField.Name, "field must be exported")
+		}
+
+		return logger, false
+	}
+
+	switch logger.backend {
+	case "zap":
+		if field.Type() != zapLoggerType && field.Type() != zapSugaredLoggerType {
+			s.fieldError(structField.Name, "zap logger must be *zap.Logger or *zap.SugaredLogger, got %s", field.Type())
+		}
+	case "slog":
+		if field.Type() != slogLoggerType {
+			s.fieldError(structField.Name, "slog logger must be *slog.Logger, got %s", field.Type())
+		}
+	}
+
+	for _, option := range options[1:] {
+		key, value, _ := strings.Cut(option, "=")
+
+		switch key {
+		case "level":
+			var err error
+			if logger.backend == "slog" {
+				err = logger.slogLevel.UnmarshalText([]byte(value))
+			} else {
+				logger.level, err = zapcore.ParseLevel(value)
+			}
+
+			if err != nil {
+				s.fieldError(structField.Name, "invalid level %q", value)
+			}
+		case "name":
+			if logger.backend != "zap" {
+				s.fieldError(structField.Name, "name option is only supported by zap loggers")
+			}
+
+			logger.name = value
+		default:
+			s.fieldError(structField.Name, "unknown option %q", option)
+		}
+	}
+
+	return logger, true
+}
+
+func isLogBackend(backend string) bool {
+	if backend == "zap" || backend == "slog" {
+		return true
+	}
+
+	logAdapters.RLock()
+	defer logAdapters.RUnlock()
+
+	_, ok := logAdapters.adapters[backend]
+
+	return ok
+}
+
+func (s *ourSuite) fieldError(fieldName, format string, args ...any) {
+	require.Fail(s.t, fmt.Sprintf("%sfield %s: %s", prefix, fieldName, fmt.Sprintf(format, args...)))
+}
+
+func (s *ourSuite) setupLoggers() {
+	if setup, ok := s.self.(SetupLoggersSuite); ok {
+		setup.SetupLoggers()
+	}
+
+	var zapLevel, slogLevel *suiteLogger
+
+	// All the loggers share the suite's Zap and Slog, so they're observed at the lowest of the requested levels.
+	for i, logger := range s.loggers {
+		if logger.backend == "slog" {
+			if slogLevel == nil || logger.slogLevel < slogLevel.slogLevel {
+				slogLevel = &s.loggers[i]
+			}
+		} else if zapLevel == nil || logger.level < zapLevel.level {
+			zapLevel = &s.loggers[i]
+		}
+	}
+
+	// Get() and GetAt() share these loggers afterwards.
+	var zapLogger *zap.Logger
+	if zapLevel != nil {
+		level := zapLevel.level
+		zapLogger = s.z.GetAt(level)
+		s.z.suiteLevel = &level
+	}
+
+	if slogLevel != nil {
+		level := slogLevel.slogLevel
+		s.sl.GetAt(level)
+		s.sl.suiteLevel = &level
+	}
+
+	for _, logger := range s.loggers {
+		var value any
+
+		switch logger.backend {
+		case "zap":
+			l := zapLogger.Named(logger.name).WithOptions(zap.IncreaseLevel(logger.level))
+			if logger.field.Type() == zapSugaredLoggerType {
+				value = l.Sugar()
+			} else {
+				value = l
+			}
+		case "slog":
+			value = slog.New(&slogHandler{recorder: s.sl.recorder(), level: logger.slogLevel})
+		default:
+			value = s.z.adapt(logger.backend, s.z.Recorder().(logRecorder).increaseLevel(LogLevel(logger.level)))
+		}
+
+		v := reflect.ValueOf(value)
+		if !v.Type().AssignableTo(logger.field.Type()) {
+			s.fieldError(logger.fieldName, "adapter %q returned %s, which isn't assignable to %s",
+				logger.backend, v.Type(), logger.field.Type())
+		}
+
+		logger.field.Set(v)
+	}
+}
@@ -0,0 +1,267 @@
+package muchtest
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockT is passed to the mock factories. Functions registered with Cleanup() run in TearDownTest, so that's
+// where the factories verify the expectations of their mocks. It satisfies both mock.TestingT and gomock.TestHelper.
+type MockT interface {
+	mock.TestingT
+	Fatalf(format string, args ...any)
+	Helper()
+	Cleanup(fn func())
+}
+
+type MockFactory interface {
+	Supports(typ reflect.Type) bool
+	New(t MockT, typ reflect.Type) reflect.Value
+}
+
+var mockFactories = struct {
+	sync.RWMutex
+	factories []MockFactory
+}{factories: []MockFactory{testifyMockFactory{}}}
+
+// RegisterMockFactory registers a factory for mocks of suite fields. Factories registered later take precedence.
+func RegisterMockFactory(factory MockFactory) {
+	mockFactories.Lock()
+	defer mockFactories.Unlock()
+
+	mockFactories.factories = append(mockFactories.factories, factory)
+}
+
+// RegisterMockConstructor registers a constructor like the NewFoo(t) ones generated by mockery. The constructor must
+// accept either *testing.T or an interface implemented by MockT, and return a single mock. Mocks generated by gomock
+// can be registered the same way: func(t muchtest.MockT) *MockFoo { return NewMockFoo(gomock.NewController(t)) }.
+func RegisterMockConstructor(constructor any) {
+	vConstructor := reflect.ValueOf(constructor)
+	constructorType := vConstructor.Type()
+
+	if constructorType.Kind() != reflect.Func || constructorType.NumIn() != 1 || constructorType.NumOut() != 1 ||
+		!isMockTParam(constructorType.In(0)) {
+		panic(fmt.Sprintf("muchtest.RegisterMockConstructor(): expected func(t) mock, got %s", constructorType))
+	}
+
+	RegisterMockFactory(mockConstructor{constructor: vConstructor})
+}
+
+func isMockTParam(typ reflect.Type) bool {
+	return typ == reflect.TypeOf((*testing.T)(nil)) || reflect.TypeOf((*mockT)(nil)).AssignableTo(typ)
+}
+
+func findMockFactory(typ reflect.Type) MockFactory {
+	mockFactories.RLock()
+	defer mockFactories.RUnlock()
+
+	for i := len(mockFactories.factories) - 1; i >= 0; i-- {
+		if factory := mockFactories.factories[i]; factory.Supports(typ) {
+			return factory
+		}
+	}
+
+	return nil
+}
+
+type testifyMockFactory struct{}
+
+func (testifyMockFactory) Supports(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return false
+	}
+
+	// Only an embedded Mock promotes the Test() and AssertExpectations() methods, a field named Mock doesn't.
+	mockField, ok := typ.Elem().FieldByName("Mock")
+
+	return ok && mockField.Anonymous && (mockField.Type == reflect.TypeOf(mock.Mock{}) || mockField.Type == reflect.TypeOf(&mock.Mock{}))
+}
+
+func (testifyMockFactory) New(t MockT, typ reflect.Type) reflect.Value {
+	vMock := reflect.New(typ.Elem())
+
+	// The Mock may be embedded through pointers, which need to be allocated first.
+	mockField, _ := typ.Elem().FieldByName("Mock")
+	v := vMock.Elem()
+
+	for _, i := range mockField.Index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	vMock.MethodByName("Test").Call([]reflect.Value{reflect.ValueOf(t)})
+	t.Cleanup(func() {
+		vMock.MethodByName("AssertExpectations").Call([]reflect.Value{reflect.ValueOf(t)})
+	})
+
+	return vMock
+}
+
+type mockConstructor struct {
+	constructor reflect.Value
+}
+
+func (c mockConstructor) Supports(typ reflect.Type) bool {
+	return c.constructor.Type().Out(0) == typ
+}
+
+func (c mockConstructor) New(t MockT, _ reflect.Type) reflect.Value {
+	vT := reflect.ValueOf(t)
+	if c.constructor.Type().In(0) == reflect.TypeOf((*testing.T)(nil)) {
+		vT = reflect.ValueOf(t.(*mockT).T)
+	}
+
+	return c.constructor.Call([]reflect.Value{vT})[0]
+}
+
+type mockT struct {
+	*testing.T
+
+	cleanups []func()
+}
+
+func (t *mockT) Cleanup(fn func()) {
+	t.cleanups = append(t.cleanups, fn)
+}
+
+func (t *mockT) cleanup() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+type suiteMock struct {
+	field     reflect.Value
+	fieldName string
+	factory   MockFactory
+	container bool
+	length    int
+	keys      []string
+}
+
+var suiteTypes = map[reflect.Type]bool{
+	reflect.TypeOf(Suite{}):       true,
+	reflect.TypeOf(suite.Suite{}): true,
+}
+
+// mockFields finds mocks in the field, or in the elements of slices, arrays and maps, and in the fields of structs.
+func (s *ourSuite) mockFields(field reflect.Value, structField reflect.StructField) []suiteMock {
+	if !field.CanSet() || suiteTypes[field.Type()] {
+		return nil
+	}
+
+	if factory := findMockFactory(field.Type()); factory != nil {
+		return []suiteMock{{field: field, fieldName: structField.Name, factory: factory}}
+	}
+
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		factory := findMockFactory(field.Type().Elem())
+		if factory == nil {
+			return nil
+		}
+
+		return []suiteMock{s.mockContainer(field, structField, factory)}
+	case reflect.Struct:
+		var mocks []suiteMock
+
+		for i := 0; i < field.NumField(); i++ {
+			mocks = append(mocks, s.mockFields(field.Field(i), field.Type().Field(i))...)
+		}
+
+		return mocks
+	default:
+		return nil
+	}
+}
+
+func (s *ourSuite) mockContainer(field reflect.Value, structField reflect.StructField, factory MockFactory) suiteMock {
+	container := suiteMock{field: field, fieldName: structField.Name, factory: factory, container: true, length: -1}
+
+	tag := structField.Tag.Get(tagName)
+	if tag == "" {
+		return container
+	}
+
+	key, value, _ := strings.Cut(tag, "=")
+
+	switch {
+	case key == "len" && field.Kind() == reflect.Slice:
+		length, err := strconv.Atoi(value)
+		if err != nil || length < 0 {
+			s.fieldError(structField.Name, "invalid len %q", value)
+		}
+
+		container.length = length
+	case key == "keys" && field.Kind() == reflect.Map && field.Type().Key().Kind() == reflect.String:
+		container.keys = strings.Split(value, "|")
+	default:
+		s.fieldError(structField.Name, "unsupported tag %q for %s", tag, field.Type())
+	}
+
+	return container
+}
+
+func (m suiteMock) create(t *mockT) {
+	newMock := func() reflect.Value {
+		return m.factory.New(t, m.field.Type().Elem())
+	}
+
+	if !m.container {
+		m.field.Set(m.factory.New(t, m.field.Type()))
+
+		return
+	}
+
+	switch m.field.Kind() {
+	case reflect.Array:
+		for i := 0; i < m.field.Len(); i++ {
+			m.field.Index(i).Set(newMock())
+		}
+	case reflect.Slice:
+		length := m.length
+		if length < 0 {
+			length = m.field.Len()
+		}
+
+		slice := reflect.MakeSlice(m.field.Type(), length, length)
+		for i := 0; i < length; i++ {
+			slice.Index(i).Set(newMock())
+		}
+
+		m.field.Set(slice)
+	default:
+		keys := m.field.MapKeys()
+		if m.keys != nil {
+			keys = keys[:0]
+			for _, key := range m.keys {
+				keys = append(keys, reflect.ValueOf(key).Convert(m.field.Type().Key()))
+			}
+		}
+
+		newMap := reflect.MakeMapWithSize(m.field.Type(), len(keys))
+		for _, key := range keys {
+			newMap.SetMapIndex(key, newMock())
+		}
+
+		m.field.Set(newMap)
+	}
+}
@@ -0,0 +1,300 @@
+package muchtest
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errorType     = reflect.TypeOf((*error)(nil)).Elem()
+	fakeClockType = reflect.TypeOf((*clockwork.FakeClock)(nil)).Elem()
+)
+
+var providers = struct {
+	sync.RWMutex
+	providers map[reflect.Type]reflect.Value
+}{providers: map[reflect.Type]reflect.Value{}}
+
+// Provide registers constructors, and plain values, used to create suite fields tagged with `muchtest:"sut"`.
+// A constructor returns the value, optionally followed by an error. Its parameters are resolved from the suite's
+// mocks and loggers, the suite's Clock() and other provided values. A provided type satisfies every type it's
+// assignable to, so that an implementation may be provided for an interface parameter; the exactly matching type is
+// preferred, otherwise more satisfying types are ambiguous.
+//
+// Every func is taken as a constructor, so a func value, such as a callback, must be wrapped in a constructor that
+// returns it:
+//
+//	muchtest.Provide(func() func() error { return callback })
+func Provide(constructorsAndValues ...any) {
+	providers.Lock()
+	defer providers.Unlock()
+
+	if err := addProviders(providers.providers, constructorsAndValues); err != "" {
+		panic("muchtest.Provide(): " + err)
+	}
+}
+
+// ProvidersSuite is implemented by suites that need their own constructors and values, see Provide(). They take
+// precedence over the ones registered with Provide(), so that suites may create the same type differently.
+type ProvidersSuite interface {
+	Providers() []any
+}
+
+// addProviders adds either all the constructors and values, or none of them when any of them is invalid.
+func addProviders(providers map[reflect.Type]reflect.Value, constructorsAndValues []any) string {
+	added := make(map[reflect.Type]reflect.Value, len(constructorsAndValues))
+
+	for _, provider := range constructorsAndValues {
+		vProvider := reflect.ValueOf(provider)
+		if !vProvider.IsValid() {
+			return "can't provide nil"
+		}
+
+		providedType := vProvider.Type()
+
+		if providedType.Kind() == reflect.Func {
+			if providedType.NumOut() == 0 || providedType.NumOut() > 2 ||
+				providedType.NumOut() == 2 && providedType.Out(1) != errorType || providedType.IsVariadic() {
+				return fmt.Sprintf("expected func(...) T or func(...) (T, error), got %s", providedType)
+			}
+
+			providedType = providedType.Out(0)
+		}
+
+		if _, exists := providers[providedType]; exists {
+			return fmt.Sprintf("%s is already provided", providedType)
+		}
+
+		if _, exists := added[providedType]; exists {
+			return fmt.Sprintf("%s is already provided", providedType)
+		}
+
+		added[providedType] = vProvider
+	}
+
+	for providedType, vProvider := range added {
+		providers[providedType] = vProvider
+	}
+
+	return ""
+}
+
+func (s *ourSuite) setupProviders() {
+	providersSuite, ok := s.self.(ProvidersSuite)
+	if !ok {
+		return
+	}
+
+	s.providers = map[reflect.Type]reflect.Value{}
+
+	if err := addProviders(s.providers, providersSuite.Providers()); err != "" {
+		require.Fail(s.t, prefix+"Providers(): "+err)
+	}
+}
+
+// findProvider returns the provider of the type, or of a type assignable to it, and the type it provides.
+func (s *ourSuite) findProvider(typ reflect.Type) (reflect.Type, reflect.Value, string) {
+	if providedType, provider, err := lookupProvider(s.providers, typ); provider.IsValid() || err != "" {
+		return providedType, provider, err
+	}
+
+	providers.RLock()
+	defer providers.RUnlock()
+
+	return lookupProvider(providers.providers, typ)
+}
+
+func lookupProvider(providers map[reflect.Type]reflect.Value, typ reflect.Type) (reflect.Type, reflect.Value, string) {
+	if provider, ok := providers[typ]; ok {
+		return typ, provider, ""
+	}
+
+	var candidates []string
+	var providedType reflect.Type
+
+	for candidate := range providers {
+		if candidate.AssignableTo(typ) {
+			candidates = append(candidates, candidate.String())
+			providedType = candidate
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, reflect.Value{}, ""
+	case 1:
+		return providedType, providers[providedType], ""
+	default:
+		sort.Strings(candidates)
+
+		return nil, reflect.Value{}, "ambiguous, it's satisfied by provided " + strings.Join(candidates, ", ")
+	}
+}
+
+type suiteSUT struct {
+	field     reflect.Value
+	fieldName string
+}
+
+func (s *ourSuite) sutField(field reflect.Value, structField reflect.StructField) (suiteSUT, bool) {
+	if structField.Tag.Get(tagName) != "sut" {
+		return suiteSUT{}, false
+	}
+
+	if !field.CanSet() {
+		s.fieldError(structField.Name, "field must be exported")
+	}
+
+	if _, provider, err := s.findProvider(field.Type()); err != "" {
+		s.fieldError(structField.Name, "%s", err)
+	} else if !provider.IsValid() {
+		s.fieldError(structField.Name, "nothing provides %s, register its constructor with muchtest.Provide()", field.Type())
+	}
+
+	return suiteSUT{field: field, fieldName: structField.Name}, true
+}
+
+type sutResolver struct {
+	s        *ourSuite
+	values   map[reflect.Type]reflect.Value
+	creating map[reflect.Type]bool
+}
+
+func (s *ourSuite) setupSUTs() {
+	if len(s.suts) == 0 {
+		return
+	}
+
+	resolver := &sutResolver{s: s, values: map[reflect.Type]reflect.Value{}, creating: map[reflect.Type]bool{}}
+
+	for _, sut := range s.suts {
+		value, err := resolver.provide(sut.field.Type())
+		if err != "" {
+			s.fieldError(sut.fieldName, "%s", err)
+		}
+
+		sut.field.Set(value)
+	}
+}
+
+// provide creates the value of the given type with its provider; values are shared within a single test.
+func (r *sutResolver) provide(typ reflect.Type) (reflect.Value, string) {
+	providedType, provider, err := r.s.findProvider(typ)
+	if err != "" {
+		return reflect.Value{}, err
+	}
+
+	if value, ok := r.values[providedType]; ok {
+		return value, ""
+	}
+
+	if provider.Kind() != reflect.Func {
+		return provider, ""
+	}
+
+	if r.creating[providedType] {
+		return reflect.Value{}, fmt.Sprintf("dependency cycle while creating %s", providedType)
+	}
+
+	r.creating[providedType] = true
+	defer delete(r.creating, providedType)
+
+	args := make([]reflect.Value, provider.Type().NumIn())
+
+	var unresolved []string
+
+	for i := range args {
+		paramType := provider.Type().In(i)
+
+		arg, err := r.resolve(paramType)
+		if err != "" {
+			unresolved = append(unresolved, fmt.Sprintf("params[%d] %s: %s", i, paramType, err))
+
+			continue
+		}
+
+		args[i] = arg
+	}
+
+	constructor := runtime.FuncForPC(provider.Pointer()).Name()
+
+	if len(unresolved) != 0 {
+		return reflect.Value{}, fmt.Sprintf("can't call %s():\n\t%s", constructor, strings.Join(unresolved, "\n\t"))
+	}
+
+	results := provider.Call(args)
+	if len(results) == 2 && !results[1].IsNil() {
+		return reflect.Value{}, fmt.Sprintf("%s() failed: %s", constructor, results[1].Interface())
+	}
+
+	r.values[providedType] = results[0]
+
+	return results[0], ""
+}
+
+func (r *sutResolver) resolve(typ reflect.Type) (reflect.Value, string) {
+	var candidates []string
+	var value reflect.Value
+
+	for _, m := range r.s.mocks {
+		if !m.container && m.field.Type().AssignableTo(typ) {
+			candidates = append(candidates, m.fieldName)
+			value = m.field
+		}
+	}
+
+	for _, logger := range r.s.loggers {
+		if logger.field.Type().AssignableTo(typ) {
+			candidates = append(candidates, logger.fieldName)
+			value = logger.field
+		}
+	}
+
+	if len(candidates) > 1 {
+		return reflect.Value{}, "ambiguous, it's satisfied by suite fields " + strings.Join(candidates, ", ")
+	}
+
+	if value.IsValid() {
+		return value, ""
+	}
+
+	switch typ {
+	case zapLoggerType, zapSugaredLoggerType:
+		logger := r.s.z.logger
+		if logger == nil {
+			logger = r.s.z.Get()
+		}
+
+		if typ == zapSugaredLoggerType {
+			return reflect.ValueOf(logger.Sugar()), ""
+		}
+
+		return reflect.ValueOf(logger), ""
+	case slogLoggerType:
+		logger := r.s.sl.logger
+		if logger == nil {
+			logger = r.s.sl.Get()
+		}
+
+		return reflect.ValueOf(logger), ""
+	}
+
+	if _, provider, err := r.s.findProvider(typ); err != "" {
+		return reflect.Value{}, err
+	} else if provider.IsValid() {
+		return r.provide(typ)
+	}
+
+	if typ.Kind() == reflect.Interface && typ.NumMethod() != 0 && fakeClockType.Implements(typ) {
+		return reflect.ValueOf(r.s.Clock()), ""
+	}
+
+	return reflect.Value{}, "no mock, logger, clock or provided value"
+}
@@ -0,0 +1,405 @@
+package muchtest_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/grongor/go-muchtest"
+	"github.com/grongor/go-muchtest/match"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	muchtest.RegisterMockConstructor(newCtorMock)
+	muchtest.RegisterMockConstructor(func(t *testing.T) *tMock {
+		return &tMock{t: t}
+	})
+	muchtest.RegisterMockConstructor(func(t muchtest.MockT) *gomockMock {
+		return newGomockMock(newGomockController(t))
+	})
+
+	muchtest.Provide(newService, newRepository, serviceConfig{prefix: "much"}, newGreeter, dogeNamer{})
+}
+
+type testMock struct {
+	mock.Mock
+}
+
+func (m *testMock) Do(value int) {
+	m.Called(value)
+}
+
+func (m *testMock) expect(value int) {
+	m.On("Do", value).Once()
+}
+
+type nestedMock struct {
+	testMock
+}
+
+type pointerMock struct {
+	*mock.Mock
+}
+
+func (m *pointerMock) Do(value int) {
+	m.Called(value)
+}
+
+// namedMock isn't a testify mock, its Mock isn't embedded.
+type namedMock struct {
+	Mock mock.Mock
+}
+
+type ctorMock struct {
+	verified bool
+}
+
+func newCtorMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ctorMock {
+	m := &ctorMock{}
+	t.Cleanup(func() { m.verified = true })
+
+	return m
+}
+
+type tMock struct {
+	t *testing.T
+}
+
+// gomockController mimics gomock.Controller, whose constructor requires Errorf(), Fatalf() and Helper(), and uses
+// Cleanup() to verify the expectations.
+type gomockController struct {
+	finished bool
+}
+
+func newGomockController(t interface {
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+	Helper()
+	Cleanup(func())
+}) *gomockController {
+	ctrl := &gomockController{}
+	t.Cleanup(func() { ctrl.finished = true })
+
+	return ctrl
+}
+
+type gomockMock struct {
+	ctrl *gomockController
+}
+
+func newGomockMock(ctrl *gomockController) *gomockMock {
+	return &gomockMock{ctrl: ctrl}
+}
+
+func TestLoggersSuite(t *testing.T) {
+	muchtest.Run(t, new(LoggersSuite))
+}
+
+type LoggersSuite struct {
+	muchtest.Suite
+
+	Logger  *zap.Logger
+	Debug   *zap.Logger `muchtest:"zap,level=debug,name=debug"`
+	Sugared *zap.SugaredLogger
+	Slog    *slog.Logger `muchtest:"slog,level=warn"`
+	Custom  *testLogger  `muchtest:"test"`
+	Quiet   *testLogger  `muchtest:"test,level=warn"`
+	Ignored *zap.Logger  `muchtest:"-"`
+
+	logger *zap.Logger
+}
+
+func (s *LoggersSuite) TestInjected() {
+	s.S.Nil(s.Ignored)
+	s.S.Nil(s.logger)
+
+	s.Logger.Debug("not observed")
+	s.Logger.Info("much", zap.Int("wow", 1))
+	s.Debug.Debug("such")
+	s.Sugared.Infow("very", "doge", true)
+	s.Custom.Log("amaze")
+	s.Quiet.Log("not observed")
+
+	s.S.Zap().AssertNext(zap.InfoLevel, "much", "wow", 1)
+	s.S.Zap().Assert("debug").Debug("such").NoCtx()
+	s.S.Zap().AssertNext(zap.InfoLevel, "very", "doge", true)
+	s.S.Zap().Assert("test").Info("amaze").NoCtx()
+
+	s.Slog.Info("not observed")
+	s.Slog.Warn("wow")
+
+	s.S.Slog().Warn("wow").NoCtx()
+}
+
+func (s *LoggersSuite) SetupLoggers() {
+	s.S.Zap().
+		Clock(s.S.Clock()).
+		Encoder(zapcore.NewConsoleEncoder(zapcore.EncoderConfig{
+			MessageKey:  "msg",
+			LevelKey:    "level",
+			EncodeLevel: zapcore.LowercaseLevelEncoder,
+		}))
+}
+
+func (s *LoggersSuite) TestSetupLoggers() {
+	s.S.Clock().Advance(time.Hour)
+	s.Logger.Info("much")
+
+	s.S.Zap().Info("much").Time(match.SameInstant(s.S.Clock().Now())).NoCtx()
+	s.S.Equal("info\tmuch", s.S.Zap().Encoded().String())
+}
+
+func (s *LoggersSuite) TestExplicitGet() {
+	s.S.Zap().Get().Info("explicit")
+	s.Logger.Info("field")
+	s.S.Zap().GetAt(zap.DebugLevel).Debug("debug")
+
+	s.S.Zap().
+		AssertNext(zap.InfoLevel, "explicit").
+		AssertNext(zap.InfoLevel, "field").
+		AssertNext(zap.DebugLevel, "debug")
+
+	s.S.Slog().GetAt(slog.LevelWarn).Warn("wow")
+	s.S.Slog().Warn("wow").NoCtx()
+}
+
+func (s *LoggersSuite) TestFreshPerTest() {
+	s.Logger.Info("much")
+
+	s.S.Zap().Info("much").NoCtx()
+}
+
+func (s *LoggersSuite) TestSubtest() {
+	s.Run("sub", func() {
+		s.Logger.Info("much")
+
+		s.S.Zap().Info("much").NoCtx()
+	})
+}
+
+func TestMocksSuite(t *testing.T) {
+	muchtest.Run(t, new(MocksSuite))
+}
+
+type MocksSuite struct {
+	muchtest.Suite
+
+	Mock    *testMock
+	Nested  *nestedMock
+	Pointer *pointerMock
+	Ctor    *ctorMock
+	TMock   *tMock
+	Gomock  *gomockMock
+	Named   *namedMock
+	Slice   []*testMock `muchtest:"len=2"`
+	Array   [2]*ctorMock
+	Map     map[string]*testMock `muchtest:"keys=primary|replica"`
+	Group   struct {
+		Mock *testMock
+	}
+
+	ctors []*ctorMock
+}
+
+func (s *MocksSuite) BeforeTest(suiteName, testName string) {
+	s.ctors = []*ctorMock{s.Ctor, s.Array[0], s.Array[1]}
+}
+
+func (s *MocksSuite) TearDownTest() {
+	for _, ctor := range s.ctors {
+		s.S.False(ctor.verified)
+	}
+
+	s.Suite.TearDownTest()
+
+	for _, ctor := range s.ctors {
+		s.S.True(ctor.verified, "constructor cleanup should run in TearDownTest")
+	}
+
+	s.S.True(s.Gomock.ctrl.finished, "controller cleanup should run in TearDownTest")
+}
+
+func (s *MocksSuite) TestMocks() {
+	testMocks := []*testMock{
+		s.Mock, &s.Nested.testMock, s.Slice[0], s.Slice[1], s.Map["primary"], s.Map["replica"], s.Group.Mock,
+	}
+
+	for _, m := range testMocks {
+		m.expect(1)
+		m.Do(1)
+		s.S.Len(1, m.Calls)
+	}
+
+	s.Pointer.On("Do", 1).Once()
+	s.Pointer.Do(1)
+
+	s.S.Len(2, s.Map)
+	s.S.True(s.Array[0] != s.Array[1])
+	s.S.Same(s.T(), s.TMock.t)
+	s.S.False(s.Gomock.ctrl.finished)
+	s.S.Nil(s.Named)
+}
+
+func (s *MocksSuite) TestFreshPerTest() {
+	s.TestMocks()
+}
+
+func (s *MocksSuite) TestRegisterMockConstructor_Invalid() {
+	s.S.PanicsWithValue("muchtest.RegisterMockConstructor(): expected func(t) mock, got func(string) int", func() {
+		muchtest.RegisterMockConstructor(func(string) int { return 0 })
+	})
+}
+
+type doer interface {
+	Do(value int)
+}
+
+type serviceConfig struct {
+	prefix string
+}
+
+type repository struct {
+	clock clockwork.Clock
+}
+
+func newRepository(clock clockwork.Clock) (*repository, error) {
+	if clock == nil {
+		return nil, errors.New("no clock")
+	}
+
+	return &repository{clock: clock}, nil
+}
+
+type service struct {
+	doer       doer
+	logger     *zap.Logger
+	clock      match.Clock
+	config     serviceConfig
+	repository *repository
+}
+
+func newService(
+	doer doer,
+	logger *zap.Logger,
+	clock match.Clock,
+	config serviceConfig,
+	repository *repository,
+) *service {
+	return &service{doer: doer, logger: logger, clock: clock, config: config, repository: repository}
+}
+
+func (s *service) run() {
+	s.doer.Do(1)
+	s.logger.Info(s.config.prefix, zap.Time("now", s.clock.Now()))
+}
+
+type namer interface {
+	Name() string
+}
+
+type dogeNamer struct{}
+
+func (dogeNamer) Name() string {
+	return "doge"
+}
+
+type greeter struct {
+	namer namer
+}
+
+func newGreeter(namer namer) *greeter {
+	return &greeter{namer: namer}
+}
+
+func TestSUTSuite(t *testing.T) {
+	muchtest.Run(t, new(SUTSuite))
+}
+
+type SUTSuite struct {
+	muchtest.Suite
+
+	Doer       *testMock
+	Service    *service    `muchtest:"sut"`
+	Repository *repository `muchtest:"sut"`
+	Greeter    *greeter    `muchtest:"sut"`
+	Namer      namer       `muchtest:"sut"`
+}
+
+func (s *SUTSuite) TestProvided() {
+	s.Doer.expect(1)
+
+	s.Service.run()
+
+	s.S.Same(s.Doer, s.Service.doer)
+	s.S.Same(s.Repository, s.Service.repository)
+	s.S.True(s.S.Clock() == s.Service.clock)
+	s.S.True(s.S.Clock() == s.Repository.clock)
+	s.S.Zap().Info("much").Ctx("now", match.SameInstant(s.S.Clock().Now()))
+
+	// Interfaces are satisfied by the provided implementations.
+	s.S.Equal(dogeNamer{}, s.Greeter.namer)
+	s.S.Equal(dogeNamer{}, s.Namer)
+}
+
+func (s *SUTSuite) TestFreshPerTest() {
+	s.TestProvided()
+}
+
+func (s *SUTSuite) TestProvide_Invalid() {
+	s.S.PanicsWithValue("muchtest.Provide(): *muchtest_test.service is already provided", func() {
+		muchtest.Provide(newService)
+	})
+
+	s.S.PanicsWithValue("muchtest.Provide(): expected func(...) T or func(...) (T, error), got func() (int, int)", func() {
+		muchtest.Provide(func() (int, int) { return 0, 0 })
+	})
+
+	s.S.PanicsWithValue("muchtest.Provide(): can't provide nil", func() {
+		muchtest.Provide(nil)
+	})
+
+	// Nothing is provided when any of the arguments is invalid, so the same call fails the same way again.
+	type atomic struct{}
+
+	for i := 0; i < 2; i++ {
+		s.S.PanicsWithValue("muchtest.Provide(): can't provide nil", func() {
+			muchtest.Provide(atomic{}, nil)
+		})
+	}
+
+	s.S.PanicsWithValue("muchtest.Provide(): muchtest_test.atomic is already provided", func() {
+		muchtest.Provide(atomic{}, atomic{})
+	})
+}
+
+func TestProvidersSuite(t *testing.T) {
+	muchtest.Run(t, new(ProvidersSuite))
+}
+
+type ProvidersSuite struct {
+	muchtest.Suite
+
+	Doer    *testMock
+	Service *service `muchtest:"sut"`
+}
+
+func (s *ProvidersSuite) Providers() []any {
+	return []any{serviceConfig{prefix: "wow"}, func() *repository { return &repository{} }}
+}
+
+func (s *ProvidersSuite) TestProvided() {
+	s.Doer.expect(1)
+
+	s.Service.run()
+
+	s.S.Nil(s.Service.repository.clock)
+	s.S.Zap().Info("wow").Ctx("now", match.SameInstant(s.S.Clock().Now()))
+}
@@ -2,40 +2,39 @@ package muchtest
 
 import (
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/grongor/go-muchtest/match"
-	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 )
 
-type zapState int
-
-const (
-	zapCreated = zapState(iota)
-	zapInitialized
-	zapAsserting
-	zapAsserted
-
-	zapPrefix = "zap: "
-)
+const zapPrefix = "zap: "
 
 func NewZap(t TestingT) *Zap {
-	return &Zap{t: t}
+	core := newLogCore(t, zapPrefix)
+	core.callerHint = " (use zap.AddCaller())"
+
+	return &Zap{t: t, core: core}
 }
 
+// Zap adapts zap to the logCore: the entries of its logger are recorded as LogRecords, and the assertions take zap
+// levels and fields.
 type Zap struct {
-	t                         TestingT
-	state                     zapState
-	logger                    *zap.Logger
-	partial, orderless, loose bool
+	t    TestingT
+	core *logCore
 
+	logger       *zap.Logger
+	level        LogLevel
+	used         bool
 	observedLogs *observer.ObservedLogs
-	index        int
-	usedIndexes  []int
-	pending      *zapEntryAssert
+
+	encoder    zapcore.Encoder
+	options    []zap.Option
+	suiteLevel *zapcore.Level
+
+	scopes []*Zap
 }
 
 func (z *Zap) Get() *zap.Logger {
@@ -44,21 +43,94 @@ func (z *Zap) Get() *zap.Logger {
 
 func (z *Zap) GetAt(level zapcore.Level) *zap.Logger {
 	z.t.Helper()
-	z.transition(zapInitialized)
+
+	// The suite has already created the logger for its logger fields, so share it instead.
+	if z.suiteLevel != nil {
+		if level < *z.suiteLevel {
+			z.core.reportError(fmt.Sprintf(
+				"GetAt(%s): the suite's logger fields are observed from the %s level", level, *z.suiteLevel,
+			))
+		}
+
+		return z.logger.WithOptions(zap.IncreaseLevel(level))
+	}
+
+	store := &logStore{}
+	z.core.initialize(store)
 
 	var core zapcore.Core
 	core, z.observedLogs = observer.New(level)
 
-	z.logger = zap.New(core, zap.WithFatalHook(zapcore.WriteThenGoexit))
+	z.level = LogLevel(level)
+	z.logger = zap.New(
+		zapRecordCore{Core: core, store: store},
+		append([]zap.Option{zap.WithFatalHook(zapcore.WriteThenGoexit)}, z.options...)...,
+	)
 
 	return z.logger
 }
 
+func (z *Zap) Encoder(encoder zapcore.Encoder) *Zap {
+	z.t.Helper()
+
+	z.core.checkNotCreated(z.suiteLevel != nil, "Encoder")
+
+	z.encoder = encoder
+
+	return z
+}
+
+func (z *Zap) Options(options ...zap.Option) *Zap {
+	z.t.Helper()
+
+	z.core.checkNotCreated(z.suiteLevel != nil, "Options")
+
+	z.options = append(z.options, options...)
+
+	return z
+}
+
+func (z *Zap) Clock(clock match.Clock) *Zap {
+	z.t.Helper()
+
+	z.core.checkNotCreated(z.suiteLevel != nil, "Clock")
+
+	z.options = append(z.options, zap.WithClock(zapClock{clock}))
+
+	return z
+}
+
+type zapClock struct {
+	match.Clock
+}
+
+func (c zapClock) NewTicker(d time.Duration) *time.Ticker {
+	return time.NewTicker(d)
+}
+
 func (z *Zap) Use(logger *zap.Logger, observedLogs *observer.ObservedLogs) *Zap {
 	z.t.Helper()
-	z.transition(zapInitialized)
+
+	// The entries are read from the observed logs as they're asserted.
+	z.core.initialize(&logStore{pull: func(records []LogRecord) []LogRecord {
+		if observedLogs == nil {
+			return records
+		}
+
+		all := observedLogs.All()
+		if len(all) < len(records) {
+			return records
+		}
+
+		for _, entry := range all[len(records):] {
+			records = append(records, zapLogRecord(entry.Entry, nil, entry.Context))
+		}
+
+		return records
+	}})
 
 	z.logger = logger
+	z.used = true
 	z.observedLogs = observedLogs
 
 	return z
@@ -66,334 +138,264 @@ func (z *Zap) Use(logger *zap.Logger, observedLogs *observer.ObservedLogs) *Zap
 
 func (z *Zap) ObservedLogs() *observer.ObservedLogs {
 	z.t.Helper()
-	z.transition(zapAsserted)
+	z.core.transition(logAsserted)
 
 	return z.observedLogs
 }
 
 func (z *Zap) Debug(message ...any) *zapEntryAssert {
-	return z.doNewAssert(nil).setLevelAndMessage(zapcore.DebugLevel, message)
+	return z.newAssert(nil).setLevelAndMessage(LogDebug, message)
 }
 
 func (z *Zap) Info(message ...any) *zapEntryAssert {
-	return z.doNewAssert(nil).setLevelAndMessage(zapcore.InfoLevel, message)
+	return z.newAssert(nil).setLevelAndMessage(LogInfo, message)
 }
 
 func (z *Zap) Warn(message ...any) *zapEntryAssert {
-	return z.doNewAssert(nil).setLevelAndMessage(zapcore.WarnLevel, message)
+	return z.newAssert(nil).setLevelAndMessage(LogWarn, message)
 }
 
 func (z *Zap) Error(message ...any) *zapEntryAssert {
-	return z.doNewAssert(nil).setLevelAndMessage(zapcore.ErrorLevel, message)
+	return z.newAssert(nil).setLevelAndMessage(LogError, message)
 }
 
 func (z *Zap) Panic(message ...any) *zapEntryAssert {
-	return z.doNewAssert(nil).setLevelAndMessage(zapcore.PanicLevel, message)
+	return z.newAssert(nil).setLevelAndMessage(LogPanic, message)
 }
 
 func (z *Zap) Fatal(message ...any) *zapEntryAssert {
-	return z.doNewAssert(nil).setLevelAndMessage(zapcore.FatalLevel, message)
+	return z.newAssert(nil).setLevelAndMessage(LogFatal, message)
 }
 
 func (z *Zap) Assert(name string) *zapEntryAssert {
-	return z.doNewAssert(&name)
+	return z.newAssert(&name)
 }
 
-func (z *Zap) doNewAssert(name *string) *zapEntryAssert {
+func (z *Zap) newAssert(name *string) *zapEntryAssert {
 	z.t.Helper()
-	z.transition(zapAsserting)
-	z.checkPendingAssert()
-
-	z.pending = &zapEntryAssert{z: z, name: name}
 
-	return z.pending
-}
-
-func (z *Zap) checkPendingAssert() {
-	if z.pending == nil {
-		return
-	}
-
-	const message = "First call one of the terminating methods(%s) on the previous assert: %s"
-	z.reportError(fmt.Sprintf(message, zapEntryAssertTerminatingMethods, z.pending.String()))
+	return &zapEntryAssert{a: z.core.newAssert(name), z: z}
 }
 
 func (z *Zap) AssertNext(level zapcore.Level, message any, ctxKeyAndValues ...any) *Zap {
 	z.t.Helper()
+	z.core.assertNext(nil, LogLevel(level), message, zapCtxArgs(ctxKeyAndValues))
 
-	return z.doAssertNext(nil, level, message, ctxKeyAndValues)
+	return z
 }
 
 func (z *Zap) AssertNamed(name string, level zapcore.Level, message any, ctxKeyAndValues ...any) *Zap {
 	z.t.Helper()
+	z.core.assertNext(&name, LogLevel(level), message, zapCtxArgs(ctxKeyAndValues))
 
-	return z.doAssertNext(&name, level, message, ctxKeyAndValues)
+	return z
 }
 
 func (z *Zap) SkipNext(n ...int) *Zap {
 	z.t.Helper()
-	z.transition(zapAsserting)
-
-	if l := len(n); l > 0 {
-		if l != 1 || n[0] < 1 {
-			z.reportError("Parameter to SkipNext() must be a single positive integer, or nothing (equivalent to 1)")
-		}
-
-		z.index += n[0]
-	} else {
-		z.index++
-	}
+	z.core.skipNext(n)
 
 	return z
 }
 
 func (z *Zap) AssertNoNextEntry() {
 	z.t.Helper()
-	z.transition(zapAsserting)
-
-	if message := z.checkNoNextEntry(); message != "" {
-		z.reportError(message)
-	}
+	z.core.assertNoNextEntry()
 }
 
-func (z *Zap) checkNoNextEntry() string {
-	l := z.observedLogs.Len()
-	if l == z.index {
-		return ""
-	}
-
-	builder := &strings.Builder{}
+func (z *Zap) Ignore(level zapcore.Level, message any, ctxKeyAndValues ...any) *Zap {
+	z.t.Helper()
+	z.core.ignore(LogLevel(level), message, zapCtxArgs(ctxKeyAndValues))
 
-	_, _ = fmt.Fprintf(builder, "AssertNoNextEntry(): there are entries available (%d)\n", l-z.index)
+	return z
+}
 
-	for _, entry := range z.observedLogs.All()[z.index:] {
-		builder.WriteByte('\t')
-		z.formatEntry(builder, entry)
-		builder.WriteByte('\n')
-	}
+func (z *Zap) IgnoreMissingContext(partial ...bool) *Zap {
+	z.t.Helper()
+	z.core.ignoreMissingContext(partial)
 
-	return builder.String()
+	return z
 }
 
-func (z *Zap) formatEntry(builder *strings.Builder, entry observer.LoggedEntry) {
-	builder.WriteByte('[')
-	builder.WriteString(entry.Time.UTC().Format("2006-01-02 15:04:05.999999"))
-	builder.WriteString("] ")
-	builder.WriteString(entry.Level.CapitalString())
-	builder.WriteString(" ")
+func (z *Zap) Orderless(orderless ...bool) *Zap {
+	z.t.Helper()
+	z.core.setOrderless(orderless)
 
-	if entry.LoggerName != "" {
-		builder.WriteByte('[')
-		builder.WriteString(entry.LoggerName)
-		builder.WriteString("] ")
-	}
+	return z
+}
 
-	builder.WriteString(entry.Message)
+func (z *Zap) Within(waitFor time.Duration, options ...PollOption) *Zap {
+	z.t.Helper()
+	z.core.setWithin(waitFor, options)
 
-	contextLen := len(entry.Context)
-	if contextLen != 0 {
-		builder.WriteString("\n\t\t[")
+	return z
+}
 
-		for k, v := range entry.ContextMap() {
-			fmt.Fprintf(builder, `"%s": "%s"`, k, v)
-			contextLen--
+func (z *Zap) MayContainMoreEntries() {
+	z.core.loose = true
+}
 
-			if contextLen != 0 {
-				builder.WriteString(", ")
-			}
-		}
+// Count returns the number of the entries with the given level that match the message and ctx, regardless of the
+// AssertNext cursor. Named scopes count only their own entries; the entries excluded by Ignore() are counted, as
+// Ignore() only lets the sequential assertions pass them.
+func (z *Zap) Count(level zapcore.Level, message any, ctxKeyAndValues ...any) int {
+	z.t.Helper()
 
-		builder.WriteByte(']')
-	}
+	return z.core.count(LogLevel(level), message, zapCtxArgs(ctxKeyAndValues))
 }
 
-func (z *Zap) IgnoreMissingContext(partial ...bool) *Zap {
+func (z *Zap) AssertCount(n int, level zapcore.Level, message any, ctxKeyAndValues ...any) *Zap {
 	z.t.Helper()
+	z.core.assertCount(n, LogLevel(level), message, zapCtxArgs(ctxKeyAndValues))
 
-	z.partial = z.getBool(partial, "IgnoreMissingContext")
+	return z
+}
+
+func (z *Zap) AssertAtMost(n int, level zapcore.Level, message any, ctxKeyAndValues ...any) *Zap {
+	z.t.Helper()
+	z.core.assertAtMost(n, LogLevel(level), message, zapCtxArgs(ctxKeyAndValues))
 
 	return z
 }
 
-func (z *Zap) Orderless(orderless ...bool) *Zap {
+// AssertNone asserts that no entries with the given level, or above, match the message and ctx.
+func (z *Zap) AssertNone(level zapcore.Level, message any, ctxKeyAndValues ...any) *Zap {
 	z.t.Helper()
+	z.core.assertNone(LogLevel(level), message, zapCtxArgs(ctxKeyAndValues))
 
-	newOrderless := z.getBool(orderless, "Orderless")
+	return z
+}
 
-	if z.orderless {
-		if newOrderless {
-			z.reportError("Orderless(): already set to orderless")
-		}
+func (z *Zap) Named(name string) *Zap {
+	z.t.Helper()
 
-		if len(z.usedIndexes) != 0 {
-			z.index += len(z.usedIndexes)
-			z.usedIndexes = nil
+	core := z.core.named(name)
 
-			if z.index != z.observedLogs.Len() {
-				z.reportError("Orderless(): some messages weren't asserted")
-			}
+	for _, scope := range z.scopes {
+		if scope.core == core {
+			return scope
 		}
-	} else if !newOrderless {
-		z.reportError("Orderless(): already set to ordered")
 	}
 
-	z.orderless = newOrderless
+	scope := *z
+	scope.core = core
+	scope.scopes = nil
 
-	return z
+	z.scopes = append(z.scopes, &scope)
+
+	return &scope
 }
 
-func (z *Zap) MayContainMoreEntries() {
-	z.loose = true
+type OrderlessZap interface {
 }
 
-func (z *Zap) transition(state zapState) {
-	if z.state == zapCreated && state != zapInitialized {
-		z.reportError("First call Get(), GetAt() or Use() methods")
-	}
+// zapRecordCore records the entries into the store, next to the observer core that backs ObservedLogs(). It keeps the
+// fields added via With() apart, so that the record knows which of its fields were inherited from the logger.
+type zapRecordCore struct {
+	zapcore.Core
 
-	if z.state >= zapInitialized && state == zapInitialized {
-		z.reportError("You can't call Get(), GetAt() or Use() multiple times")
-	}
-
-	if z.state == zapAsserting && state == zapAsserted || z.state == zapAsserted && state == zapAsserting {
-		z.reportError("You can't combine ObservedLogs() and AssertNext*() methods")
-	}
+	store *logStore
+	with  []zapcore.Field
+}
 
-	if z.state == zapAsserted && state == zapAsserted {
-		z.reportError("You can't call ObservedLogs() multiple times")
-	}
+func (c zapRecordCore) With(fields []zapcore.Field) zapcore.Core {
+	c.with = append(c.with[:len(c.with):len(c.with)], fields...)
 
-	z.state = state
+	return c
 }
 
-func (z *Zap) doAssertNext(name *string, level zapcore.Level, message any, ctxKeyAndValues []any) *Zap {
-	if len(ctxKeyAndValues) == 1 {
-		if matcher, ok := ctxKeyAndValues[0].(match.Matcher); ok {
-			return z.doAssert(name, level, message, matcher)
-		}
+func (c zapRecordCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
 	}
 
-	return z.doAssert(name, level, message, match.Fn(func(ctxMap map[string]any) (bool, string) {
-		if len(ctxKeyAndValues)%2 != 0 {
-			n := "AssertNext"
-			if name != nil {
-				n = "AssertNamed"
-			}
-
-			return false, n + `(): ctxKeyAndValues must be pairs; eg.: "duration", 5*time.Second, "status", "ok"`
-		}
+	return checked
+}
 
-		if !z.partial {
-			if ok, desc := match.Len(len(ctxKeyAndValues) / 2).Matches(ctxMap); !ok {
-				return ok, desc
-			}
-		}
+func (c zapRecordCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	c.store.add(zapLogRecord(entry, c.with, fields))
 
-		for i := 1; i < len(ctxKeyAndValues); i += 2 {
-			if ok, desc := match.ContainsKeyValue(ctxKeyAndValues[i-1], ctxKeyAndValues[i]).Matches(ctxMap); !ok {
-				return ok, desc
-			}
-		}
-
-		return true, ""
-	}))
+	return c.Core.Write(entry, append(c.with[:len(c.with):len(c.with)], fields...))
 }
 
-func (z *Zap) doAssert(name *string, level zapcore.Level, message any, ctxMatcher match.Matcher) *Zap {
-	z.transition(zapAsserting)
+// zapSource is what zap logged, kept in the LogRecord so that Encoded() encodes the entry as zap would.
+type zapSource struct {
+	entry  zapcore.Entry
+	fields []zapcore.Field
+}
 
-	if z.orderless {
-	Remaining:
-		for i := z.index; i < z.observedLogs.Len(); i++ {
-			for _, j := range z.usedIndexes {
-				if i == j {
-					continue Remaining
-				}
-			}
+func zapLogRecord(entry zapcore.Entry, with, fields []zapcore.Field) LogRecord {
+	all := append(with[:len(with):len(with)], fields...)
+
+	record := LogRecord{
+		Time:       entry.Time,
+		Level:      LogLevel(entry.Level),
+		LoggerName: entry.LoggerName,
+		Message:    entry.Message,
+		Stack:      entry.Stack,
+		Attrs:      logMapAttrs(zapContextMap(all)),
+		source:     zapSource{entry: entry, fields: all},
+	}
 
-			entry := z.observedLogs.All()[i]
+	if entry.Caller.Defined {
+		record.CallerFile = entry.Caller.File
+		record.CallerLine = entry.Caller.Line
+	}
 
-			if ok, _ := z.checkEntry(entry, name, level, message, ctxMatcher); ok {
-				z.usedIndexes = append(z.usedIndexes, i)
+	if len(with) != 0 {
+		record.with = logMapAttrs(zapContextMap(with))
+		record.call = logMapAttrs(zapContextMap(fields))
+	}
 
-				return z
-			}
-		}
+	return record
+}
 
-		if z.loose {
-			return z
-		}
+func zapContextMap(fields []zapcore.Field) map[string]any {
+	enc := zapcore.NewMapObjectEncoder()
 
-		z.reportError("No entries matched and there are no more logged entries")
+	for _, field := range fields {
+		field.AddTo(enc)
 	}
 
-	if z.index == z.observedLogs.Len() {
-		if z.loose {
-			return z
-		}
+	return enc.Fields
+}
 
-		z.reportError("There are no more logged entries")
-	}
+// zapCtxArgs turns the zapcore.Field(s) among the ctx keys and values into pairs, so that the logCore gets only pairs
+// (or a single matcher). Fields share a single encoder, so that zap.Namespace nests the fields that follow it.
+func zapCtxArgs(ctxKeyAndValues []any) []any {
+	var enc *zapcore.MapObjectEncoder
 
-	entry := z.observedLogs.All()[z.index]
-	z.index++
+	args := make([]any, 0, len(ctxKeyAndValues))
 
-	if ok, desc := z.checkEntry(entry, name, level, message, ctxMatcher); !ok {
-		z.reportError(strings.TrimPrefix(desc, "Fn(): "))
-	}
+	for i := 0; i < len(ctxKeyAndValues); i++ {
+		if field, ok := ctxKeyAndValues[i].(zapcore.Field); ok {
+			if enc == nil {
+				enc = zapcore.NewMapObjectEncoder()
+			}
 
-	return z
-}
+			field.AddTo(enc)
 
-func (z *Zap) checkEntry(
-	entry observer.LoggedEntry,
-	name *string,
-	level zapcore.Level,
-	message any,
-	ctxMatcher match.Matcher,
-) (bool, string) {
-	if name != nil {
-		if ok, desc := match.Equal(*name).Matches(entry.LoggerName); !ok {
-			return ok, desc
+			continue
 		}
-	}
-
-	if ok, desc := match.Equal(level).Matches(entry.Level); !ok {
-		return ok, desc
-	}
 
-	if ok, desc := match.ToMatcher(message).Matches(entry.Message); !ok {
-		return ok, desc
-	}
+		// A key without a value keeps the args odd, for the assertion to report it.
+		if i+1 == len(ctxKeyAndValues) {
+			args = append(args, ctxKeyAndValues[i])
 
-	if ok, desc := ctxMatcher.Matches(entry.ContextMap()); !ok {
-		desc += "\n"
+			break
+		}
 
-		return ok, desc
+		args = append(args, ctxKeyAndValues[i], ctxKeyAndValues[i+1])
+		i++
 	}
 
-	return true, ""
-}
-
-func (z *Zap) getBool(b []bool, caller string) bool {
-	switch len(b) {
-	case 0:
-		return true
-	case 1:
-		return b[0]
-	default:
-		z.reportError(caller + "(): parameter must be a single bool, or nothing (equivalent to true)")
-
-		return false
+	if enc == nil {
+		return ctxKeyAndValues
 	}
-}
 
-type OrderlessZap interface {
-}
+	for _, attr := range logMapAttrs(enc.Fields) {
+		args = append(args, attr.Key, attr.Value)
+	}
 
-func (z *Zap) reportError(err string) {
-	require.Fail(z.t, prefix+zapPrefix+err)
-	//z.assert.Fail("muchtest: zap: " + err)
-	//z.t.Errorf("muchtest: zap: %s", err)
-	//z.t.FailNow()
+	return args
 }
@@ -0,0 +1,166 @@
+package muchtest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grongor/go-muchtest/match"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Encoded returns the entries as the encoder (JSON, by default) encodes them. They're encoded only when they're read.
+func (z *Zap) Encoded() *ZapEncoded {
+	z.t.Helper()
+
+	if z.core.state == logCreated {
+		z.core.reportError("First call Get() or GetAt() methods")
+	}
+
+	if z.used {
+		z.core.reportError("Encoded() is only available with Get() or GetAt(), not with Use()")
+	}
+
+	return newZapEncoded(z.core, z.encoder)
+}
+
+func newZapEncoded(core *logCore, encoder zapcore.Encoder) *ZapEncoded {
+	if encoder == nil {
+		encoder = zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	}
+
+	return &ZapEncoded{core: core, encoder: encoder}
+}
+
+type ZapEncoded struct {
+	core    *logCore
+	encoder zapcore.Encoder
+}
+
+// Lines returns a line per entry; an entry the encoder spreads over multiple lines, eg. because of its stack, is still
+// a single line.
+func (e *ZapEncoded) Lines() []string {
+	records := e.core.store.all()
+	if len(records) == 0 {
+		return nil
+	}
+
+	lines := make([]string, len(records))
+	for i, record := range records {
+		lines[i] = e.encode(record)
+	}
+
+	return lines
+}
+
+func (e *ZapEncoded) encode(record LogRecord) string {
+	source, ok := record.source.(zapSource)
+	if !ok {
+		source.entry = zapcore.Entry{
+			Level:      zapcore.Level(record.Level),
+			Time:       record.Time,
+			LoggerName: record.LoggerName,
+			Message:    record.Message,
+			Stack:      record.Stack,
+		}
+
+		if record.CallerFile != "" {
+			source.entry.Caller = zapcore.NewEntryCaller(0, record.CallerFile, record.CallerLine, true)
+		}
+
+		source.fields = logFields(record.Attrs)
+	}
+
+	buffer, err := e.encoder.EncodeEntry(source.entry, source.fields)
+	if err != nil {
+		e.core.reportError(fmt.Sprintf("Encoded(): failed to encode %q: %s", record.Message, err))
+	}
+
+	defer buffer.Free()
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+func (e *ZapEncoded) Len() int {
+	return len(e.Lines())
+}
+
+func (e *ZapEncoded) String() string {
+	return strings.Join(e.Lines(), "\n")
+}
+
+func (e *ZapEncoded) Line(i int) *ZapEncodedLine {
+	e.core.t.Helper()
+
+	lines := e.Lines()
+	if i < 0 || i >= len(lines) {
+		e.core.reportError(fmt.Sprintf("Encoded().Line(%d): %d lines were logged", i, len(lines)))
+	}
+
+	return &ZapEncodedLine{core: e.core, i: i, line: lines[i]}
+}
+
+type ZapEncodedLine struct {
+	core *logCore
+	i    int
+	line string
+}
+
+func (l *ZapEncodedLine) Equal(expected string) *ZapEncodedLine {
+	l.core.t.Helper()
+
+	return l.Match(match.Equal(expected))
+}
+
+func (l *ZapEncodedLine) JSON(expected any) *ZapEncodedLine {
+	l.core.t.Helper()
+
+	if matcher, ok := expected.(match.Matcher); ok {
+		return l.Match(matcher)
+	}
+
+	return l.Match(match.JSON(expected))
+}
+
+func (l *ZapEncodedLine) Match(matcher any) *ZapEncodedLine {
+	l.core.t.Helper()
+
+	if ok, desc := match.ToMatcher(matcher).Matches(l.line); !ok {
+		l.core.reportError(fmt.Sprintf("Encoded().Line(%d): %s", l.i, desc))
+	}
+
+	return l
+}
+
+func (l *ZapEncodedLine) String() string {
+	return l.line
+}
+
+// logFields returns the attrs of a record, which wasn't logged by zap, as zap fields.
+func logFields(attrs []LogAttr) []zapcore.Field {
+	fields := make([]zapcore.Field, 0, len(attrs))
+
+	for _, attr := range attrs {
+		group, isGroup := attr.Value.([]LogAttr)
+
+		switch {
+		case isGroup && len(group) == 0:
+		case isGroup && attr.Key == "":
+			fields = append(fields, logFields(group)...)
+		case isGroup:
+			groupFields := logFields(group)
+
+			fields = append(fields, zap.Object(attr.Key, zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+				for _, field := range groupFields {
+					field.AddTo(enc)
+				}
+
+				return nil
+			})))
+		case attr.Key != "":
+			fields = append(fields, zap.Any(attr.Key, attr.Value))
+		}
+	}
+
+	return fields
+}
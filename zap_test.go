@@ -4,13 +4,17 @@ import (
 	"regexp"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/grongor/go-muchtest"
 	"github.com/grongor/go-muchtest/internal"
 	"github.com/grongor/go-muchtest/match"
 	"github.com/grongor/go-muchtest/mocks"
+	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
 )
 
 func TestZapSuite(t *testing.T) {
@@ -87,7 +91,7 @@ func (s *ZapSuite) TestAssertNext() {
 
 	logger.Infow("msg")
 	s.expectErr(
-		`Equal(zapcore.Level(warn)): not equal: zapcore.Level(info)`,
+		`Equal(muchtest.LogLevel(warn)): not equal: muchtest.LogLevel(info)`,
 		func() { s.z.AssertNext(zap.WarnLevel, "") },
 	)
 
@@ -133,7 +137,7 @@ func (s *ZapSuite) TestAssertNextNamed() {
 
 	logger.Infow("msg")
 	s.expectErr(
-		`Equal(zapcore.Level(warn)): not equal: zapcore.Level(info)`,
+		`Equal(muchtest.LogLevel(warn)): not equal: muchtest.LogLevel(info)`,
 		func() { s.z.AssertNamed(name, zap.WarnLevel, "") },
 	)
 
@@ -170,6 +174,12 @@ func (s *ZapSuite) TestSkipNext() {
 	logger.Info("msg")
 	s.z.AssertNoNextEntry()
 
+	s.z.SkipNext()
+	s.z.AssertNoNextEntry()
+	logger.Info("skipped")
+	logger.Info("asserted")
+	s.z.AssertNext(zap.InfoLevel, "asserted")
+
 	s.expectErr(
 		"Parameter to SkipNext() must be a single positive integer, or nothing (equivalent to 1)",
 		func() { s.z.SkipNext(0) },
@@ -228,7 +238,10 @@ func (s *ZapSuite) TestOrderless() {
 	logger.Info("info")
 
 	s.expectErr("Orderless(): already set to ordered", func() { s.z.Orderless(false) })
-	s.expectErr(`Equal(zapcore.Level(info)): not equal: zapcore.Level(warn)`, func() { s.z.AssertNext(zap.InfoLevel, "info") })
+	s.expectErr(
+		`Equal(muchtest.LogLevel(info)): not equal: muchtest.LogLevel(warn)`,
+		func() { s.z.AssertNext(zap.InfoLevel, "info") },
+	)
 	s.z.SkipNext()
 
 	logger.Info("msg2")
@@ -240,6 +253,454 @@ func (s *ZapSuite) TestOrderless() {
 	s.expectErr(`No entries matched and there are no more logged entries`, func() { s.z.AssertNext(zap.InfoLevel, "") })
 }
 
+func (s *ZapSuite) TestWithin() {
+	logger := s.z.Get().Sugar()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		logger.Info("started")
+		logger.Infow("working", "jobs", 2)
+	}()
+
+	s.z.Within(time.Second).Info("started").NoCtx()
+	s.z.Within(time.Second).AssertNext(zap.InfoLevel, "working", "jobs", 2)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		logger.Warn("late")
+	}()
+
+	s.z.Orderless()
+	s.z.Within(time.Second).Warn("late").NoCtx()
+	s.z.Orderless(false)
+
+	clock := clockwork.NewFakeClock()
+
+	go func() {
+		<-clock.After(time.Minute)
+		logger.Error("timeout")
+	}()
+
+	s.z.Within(time.Hour, muchtest.AdvanceClock(clock), muchtest.Tick(time.Second)).AssertNext(zap.ErrorLevel, "timeout")
+}
+
+func (s *ZapSuite) TestWithin_Timeout() {
+	logger := s.z.Get().Sugar()
+	clock := clockwork.NewFakeClock()
+
+	s.expectErr("Within(1s): expected entry wasn't logged in time (3 polls), no entries were logged", func() {
+		s.z.Within(time.Second, muchtest.AdvanceClock(clock), muchtest.Tick(500*time.Millisecond)).Info("started").NoCtx()
+	})
+
+	logger.Info("started")
+	s.z.Info("started").NoCtx()
+
+	s.expectErr(
+		"Within(1s): expected entry wasn't logged in time (3 polls), entries logged so far (1):",
+		func() {
+			s.z.Within(time.Second, muchtest.AdvanceClock(clock), muchtest.Tick(500*time.Millisecond)).
+				AssertNext(zap.InfoLevel, "stopped")
+		},
+	)
+
+	s.z.Orderless()
+	s.expectErr("Within(1s): expected entry wasn't logged in time (3 polls)", func() {
+		s.z.Within(time.Second, muchtest.AdvanceClock(clock), muchtest.Tick(500*time.Millisecond)).Info("stopped").NoCtx()
+	})
+
+	s.expectErr("Within(): tick must be positive, got 0s", func() {
+		s.z.Within(time.Second, muchtest.AdvanceClock(clock), muchtest.Tick(0)).Info("stopped").NoCtx()
+	})
+}
+
+func (s *ZapSuite) TestEncoded() {
+	logger := s.z.Get()
+	logger.Info("much", zap.Int("wow", 1), zap.Object("doge", zapcore.ObjectMarshalerFunc(
+		func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("name", "Shiba")
+
+			return nil
+		},
+	)))
+	logger.Warn("such")
+
+	s.S.Equal(2, s.z.Encoded().Len())
+	s.z.Encoded().Line(0).JSON(map[string]any{
+		"level": "info",
+		"ts":    match.Positive(),
+		"msg":   "much",
+		"wow":   1,
+		"doge":  map[string]any{"name": "Shiba"},
+	})
+	s.z.Encoded().Line(1).JSON(match.JSONPath("$.level", "warn"))
+
+	s.expectErr(`Encoded().Line(1): JSONPath("$.msg", JSON("wow")): not matched: JSON("wow"): not equal: "such"`, func() {
+		s.z.Encoded().Line(1).JSON(match.JSONPath("$.msg", "wow"))
+	})
+
+	s.expectErr("Encoded().Line(2): 2 lines were logged", func() {
+		s.z.Encoded().Line(2)
+	})
+
+	s.z.MayContainMoreEntries()
+}
+
+func (s *ZapSuite) TestEncoded_Console() {
+	config := zap.NewDevelopmentEncoderConfig()
+	config.TimeKey = ""
+
+	logger := s.z.Encoder(zapcore.NewConsoleEncoder(config)).Options(zap.AddCaller()).Get()
+	logger.Info("much", zap.Int("wow", 1))
+
+	s.z.Encoded().Line(0).Match(match.Regexp(`^INFO\t\S*zap_test\.go:\d+\tmuch\t\{"wow": 1\}$`))
+	s.z.Info("much").Ctx("wow", 1)
+
+	s.expectErr("Encoder() must be called before Get() or GetAt()", func() {
+		s.z.Encoder(zapcore.NewJSONEncoder(config))
+	})
+	s.expectErr("Options() must be called before Get() or GetAt()", func() {
+		s.z.Options(zap.AddCaller())
+	})
+}
+
+func (s *ZapSuite) TestEncoded_Stacktrace() {
+	config := zap.NewDevelopmentEncoderConfig()
+	config.TimeKey = ""
+
+	logger := s.z.Encoder(zapcore.NewConsoleEncoder(config)).Options(zap.AddStacktrace(zap.ErrorLevel)).Get()
+	logger.Error("much")
+	logger.Info("wow")
+
+	s.S.Equal(2, s.z.Encoded().Len())
+	s.z.Encoded().Line(0).Match(match.Regexp(`(?s)^ERROR\tmuch\n\S+TestEncoded_Stacktrace\n.+zap_test\.go:\d+`))
+	s.z.Encoded().Line(1).Equal("INFO\twow")
+	s.z.MayContainMoreEntries()
+}
+
+type countingEncoder struct {
+	zapcore.Encoder
+
+	encoded *int
+}
+
+func (e countingEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	*e.encoded++
+
+	return e.Encoder.EncodeEntry(entry, fields)
+}
+
+func (s *ZapSuite) TestEncoded_Lazy() {
+	encoded := 0
+	encoder := countingEncoder{Encoder: zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), encoded: &encoded}
+
+	logger := s.z.Encoder(encoder).Get()
+	logger.Info("much")
+	logger.Info("wow")
+
+	s.S.Zero(encoded)
+
+	s.z.Encoded().Line(1).JSON(match.JSONPath("$.msg", "wow"))
+	s.S.Equal(2, encoded)
+	s.z.MayContainMoreEntries()
+}
+
+func (s *ZapSuite) TestEncoded_Use() {
+	s.expectErr("First call Get() or GetAt() methods", func() { s.z.Encoded() })
+
+	s.z.Use(nil, nil)
+	s.expectErr("Encoded() is only available with Get() or GetAt(), not with Use()", func() { s.z.Encoded() })
+}
+
+func (s *ZapSuite) TestCallerStackAndTime() {
+	clock := clockwork.NewFakeClock()
+	logger := s.z.Clock(clock).Options(zap.AddCaller(), zap.AddStacktrace(zap.ErrorLevel)).Get()
+
+	logger.Info("much")
+	clock.Advance(time.Minute)
+	logger.Error("wow")
+
+	s.z.Info("much").Caller(match.Suffix("zap_test.go")).Stack("").Time(match.SameInstant(clock.Now().Add(-time.Minute))).
+		NoCtx()
+	s.z.Error("wow").Caller(match.Suffix("zap_test.go")).
+		Stack(match.Contains("go-muchtest_test.(*ZapSuite).TestCallerStackAndTime")).
+		Time(match.RecentlyOn(clock, 0)).
+		NoCtx()
+
+	logger.Info("such")
+	s.expectErr(`Caller(): Suffix("handler.go"): not suffixed:`, func() {
+		s.z.Info("such").Caller(match.Suffix("handler.go")).NoCtx()
+	})
+}
+
+func (s *ZapSuite) TestCaller_NotRecorded() {
+	s.z.Get().Info("much")
+
+	s.expectErr("Caller(): entry has no caller (use zap.AddCaller())", func() {
+		s.z.Info("much").Caller(match.Suffix("zap_test.go")).NoCtx()
+	})
+}
+
+func (s *ZapSuite) TestCount() {
+	s.expectErr("First call Get(), GetAt() or Use() methods", func() { s.z.Count(zap.InfoLevel, nil) })
+
+	logger := s.z.Get().Sugar()
+	logger.Warnw("retry", "attempt", 1)
+	logger.Info("much")
+	logger.Warnw("retry", "attempt", 2)
+	logger.Errorw("retry", "attempt", 3)
+
+	s.S.Equal(1, s.z.Count(zap.InfoLevel, nil))
+	s.S.Equal(2, s.z.Count(zap.WarnLevel, "retry"))
+	s.S.Equal(1, s.z.Count(zap.ErrorLevel, "retry"))
+	s.S.Equal(1, s.z.Count(zap.WarnLevel, nil, "attempt", 2))
+	s.S.Equal(2, s.z.Count(zap.WarnLevel, match.Any(), match.ContainsKeyValue("attempt", match.Less(3))))
+
+	s.z.AssertNext(zap.WarnLevel, "retry", "attempt", 1)
+
+	s.z.AssertCount(2, zap.WarnLevel, "retry").
+		AssertAtMost(1, zap.ErrorLevel, nil).
+		AssertAtMost(0, zap.DebugLevel, nil).
+		AssertNone(zap.DPanicLevel, nil).
+		AssertNone(zap.InfoLevel, match.Prefix("wow"))
+
+	s.z.AssertNext(zap.InfoLevel, "much")
+
+	s.expectErr("AssertNone(Error): 1 entry matched:", func() {
+		s.z.AssertNone(zap.ErrorLevel, nil)
+	})
+
+	s.expectErr("AssertNone(Warn): 3 entries matched:", func() {
+		s.z.AssertNone(zap.WarnLevel, "retry")
+	})
+
+	s.expectErr("AssertAtMost(1, Warn): 2 entries matched:", func() {
+		s.z.AssertAtMost(1, zap.WarnLevel, "retry")
+	})
+
+	s.expectErr("AssertCount(1, Info): no entries matched", func() {
+		s.z.AssertCount(1, zap.InfoLevel, "wow")
+	})
+
+	s.z.MayContainMoreEntries()
+}
+
+func (s *ZapSuite) TestIgnore() {
+	logger := s.z.Ignore(zap.DebugLevel, "heartbeat").Ignore(zap.InfoLevel, nil, "component", "metrics").GetAt(zap.DebugLevel).Sugar()
+
+	logger.Debug("heartbeat")
+	logger.Info("started")
+	logger.Infow("flushed", "component", "metrics", "count", 5)
+	logger.Debug("heartbeat")
+	logger.Warn("heartbeat")
+	logger.Info("stopped")
+
+	s.z.AssertNext(zap.InfoLevel, "started").
+		AssertNext(zap.WarnLevel, "heartbeat")
+
+	s.S.Equal(2, s.z.Count(zap.DebugLevel, "heartbeat"))
+
+	s.expectErr("AssertNoNextEntry(): there are entries available (1) (3 entries ignored)", func() {
+		s.z.AssertNoNextEntry()
+	})
+
+	s.z.Info("stopped").NoCtx()
+
+	s.expectErr("There are no more logged entries (3 entries ignored)", func() {
+		s.z.Debug("heartbeat").NoCtx()
+	})
+
+	s.S.IgnoreZap(zap.DebugLevel, "heartbeat")
+	s.S.Zap().GetAt(zap.DebugLevel).Debug("heartbeat")
+}
+
+func (s *ZapSuite) TestIgnore_AfterAsserting() {
+	logger := s.z.Get()
+
+	logger.Info("hb")
+	logger.Info("x")
+	logger.Info("y")
+	logger.Info("hb")
+
+	s.z.Info("hb").NoCtx()
+	s.z.Ignore(zap.InfoLevel, "hb")
+
+	s.expectErr(`Equal("y"): not equal: "x"`, func() {
+		s.z.Info("y").NoCtx()
+	})
+
+	s.z.Info("y").NoCtx()
+	s.z.AssertNoNextEntry()
+}
+
+func (s *ZapSuite) TestNestedCtx() {
+	logger := s.z.Get()
+	user := zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+		enc.AddInt("id", 42)
+
+		return enc.AddArray("roles", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+			enc.AppendString("admin")
+			enc.AppendString("dev")
+
+			return nil
+		}))
+	})
+
+	logger.Info("much", zap.Namespace("request"), zap.Object("user", user), zap.Ints("ids", []int{1, 2}))
+	logger.Info("wow", zap.Object("user", user))
+	logger.Info("such", zap.Object("user", user))
+
+	s.z.Info("much").
+		Ctx("request.user.id", 42, "request.user.roles.1", "dev", "request.ids", match.Each(match.Positive()))
+	s.z.Info("wow").Ctx(zap.Object("user", user))
+
+	s.expectErr(`Ctx("user.roles.2"): path not found`, func() {
+		s.z.Info("such").Ctx("user.roles.2", "ops")
+	})
+}
+
+func (s *ZapSuite) TestNestedCtx_Mismatch() {
+	logger := s.z.Get()
+	logger.Info("much", zap.Namespace("request"), zap.Int("id", 1))
+	logger.Info("much", zap.Namespace("request"), zap.Int("id", 1))
+
+	s.z.Info("much").Ctx(zap.Namespace("request"), zap.Int("id", 1))
+
+	s.expectErr(`Ctx("request.id"): Equal(2): not equal: 1`, func() {
+		s.z.Info("much").Ctx("request.id", 2)
+	})
+}
+
+func (s *ZapSuite) TestNamed() {
+	logger := s.z.Get()
+	api := logger.Named("api")
+	db := logger.Named("db")
+
+	api.Info("request")
+	db.Info("query", zap.Int("rows", 1))
+	api.Named("auth").Warn("denied")
+	logger.Info("started")
+	db.Info("query", zap.Int("rows", 2))
+
+	s.z.Named("db").
+		AssertNext(zap.InfoLevel, "query", "rows", 1).
+		AssertNext(zap.InfoLevel, "query", "rows", 2)
+
+	s.z.Assert("api").Info("request").NoCtx()
+	s.z.Assert("api.*").Warn("denied").NoCtx()
+	s.z.AssertNext(zap.InfoLevel, "started")
+
+	s.S.Same(s.z.Named("db"), s.z.Named("db"))
+
+	db.Info("query", zap.Int("rows", 3))
+	s.S.Equal(1, s.z.Named("db").Count(zap.InfoLevel, nil, "rows", 3))
+	s.S.Equal(3, s.z.Count(zap.InfoLevel, "query"))
+
+	s.expectErr(`Named("db"): AssertNoNextEntry(): there are entries available (1)`, func() {
+		s.z.AssertNoNextEntry()
+	})
+
+	s.z.Named("db").SkipNext()
+
+	api.Info("request")
+	s.expectErr(`Name("db.*"): logger name not matched: "api"`, func() {
+		s.z.Assert("db.*").Info("request").NoCtx()
+	})
+}
+
+func (s *ZapSuite) TestNamed_AfterAsserting() {
+	logger := s.z.Get()
+	db := logger.Named("db")
+
+	db.Info("query")
+	logger.Info("started")
+	logger.Info("stopped")
+
+	s.z.Assert("db").Info("query").NoCtx()
+
+	s.z.Named("db").AssertNoNextEntry()
+	s.z.Info("started").NoCtx()
+
+	s.z.Ignore(zap.InfoLevel, "ping")
+	db.Info("ping")
+	s.z.Named("db").AssertNoNextEntry()
+
+	s.z.Info("stopped").NoCtx()
+	s.z.AssertNoNextEntry()
+}
+
+func (s *ZapSuite) TestWith() {
+	logger := s.z.Get().With(zap.String("requestID", "abc"))
+
+	logger.Info("much", zap.Int("wow", 1))
+	logger.With(zap.String("user", "doge")).Info("such")
+	logger.Info("very", zap.String("requestID", "def"))
+
+	s.z.Info("much").With("requestID", "abc").Ctx("wow", 1)
+	s.z.Info("such").With("requestID", "abc", "user", "doge").NoCtx()
+
+	s.expectErr(`With(): ContainsKeyValue("requestID", "def"): value not matched:`, func() {
+		s.z.Info("very").With("requestID", "def").Ctx("requestID", "def")
+	})
+}
+
+func (s *ZapSuite) TestMsgf() {
+	logger := s.z.Get().Sugar()
+	logger.Infof("user %s failed %d times", "doge", 3)
+	logger.Warnf("user %s failed %d times", "doge", 4)
+
+	var user string
+
+	s.z.Info().Msgf("user %s failed %d times").NoCtx()
+	s.z.Warn().Msgf("user %s failed %d times", &user, match.Greater(3)).NoCtx()
+	s.S.Equal("doge", user)
+
+	logger.Infof("user %s failed %d times", "doge", 1)
+	s.expectErr(`Format("user %s failed %d times", Equal("doge"), Greater(1)): not matched: "user doge failed 1 times"`, func() {
+		s.z.Info().Msgf("user %s failed %d times", "doge", match.Greater(1)).NoCtx()
+	})
+
+	// The message matches, but the entry doesn't, so nothing is captured.
+	logger.Infow("user cat failed 5 times", "much", 1)
+	s.expectErr(`Len(0): got 1: map[string]any{"much":1}`, func() {
+		s.z.Info().Msgf("user %s failed %d times", &user, 5).NoCtx()
+	})
+	s.S.Equal("doge", user)
+}
+
+func (s *ZapSuite) TestNoMatchReport() {
+	logger := s.z.Get().Sugar()
+	logger.Warnw("wow", "b", 2, "a", 1)
+	logger.Infow("user logged out", "user", "doge")
+	logger.Infow("user logged in", "user", "cat", "ip", "127.0.0.1")
+
+	s.z.Orderless()
+
+	s.expectErr(
+		regexp.MustCompile(`(?s)No entries matched and there are no more logged entries\. Closest entry:\s+`+
+			`\[[^]]+\] INFO user logged out\s+\["user": "doge"\]\s+Mismatches:\s+`+
+			`message: Equal\("user logged in"\): not equal: "user logged out"\s+`+
+			`Other entries \(2, most similar first\):\s+`+
+			`\[[^]]+\] INFO user logged in\s+\["ip": "127\.0\.0\.1", "user": "cat"\]\s+`+
+			`\[[^]]+\] WARN wow\s+\["a": "1", "b": "2"\]`),
+		func() {
+			s.z.Info("user logged in").Ctx("user", "doge")
+		},
+	)
+}
+
+func (s *ZapSuite) TestMismatches() {
+	s.z.Get().Warn("wow")
+
+	s.expectErr(
+		regexp.MustCompile(`(?s)Equal\(muchtest\.LogLevel\(info\)\): not equal: muchtest\.LogLevel\(warn\).*`+
+			`Mismatches:\s+level: Equal\(muchtest\.LogLevel\(info\)\): not equal: muchtest\.LogLevel\(warn\)\s+`+
+			`message: Equal\("much"\): not equal: "wow"`),
+		func() {
+			s.z.Info("much").NoCtx()
+		},
+	)
+}
+
 func (s *ZapSuite) expectErr(desc any, fn func()) {
 	s.T().Helper()
 
@@ -256,8 +717,7 @@ func (s *ZapSuite) expectErr(desc any, fn func()) {
 			return regexp.MustCompile(`(?s)\s*Error Trace:(.*?)Error:\s+muchtest: zap: ` + regexp.QuoteMeta(x)).
 				MatchString(internal.TrimDiff(actual))
 		case *regexp.Regexp:
-			panic("wow")
-			//return x.MatchString(internal.TrimDiff(actual))
+			return x.MatchString(internal.TrimDiff(actual))
 		default:
 			return false
 		}